@@ -0,0 +1,209 @@
+package bitfield
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// This file holds multi-way set operations, which combine any number of bitlists in a single pass
+// over their words instead of chaining pairwise operations. Length mismatches are reported as
+// ErrBitlistDifferentLength, wrapped with the index of the offending argument in srcs.
+
+// UnionAll computes the OR result of all srcs (union), storing it in dst. It is safe for dst to be
+// one of srcs. With no srcs, dst is cleared. This function will return an error if any of the
+// bitlists is not the same length as dst.
+func UnionAll(dst *Bitlist64, srcs ...*Bitlist64) error {
+	if err := checkBitlist64Lengths(dst.Len(), srcs); err != nil {
+		return err
+	}
+
+	for idx := range dst.data {
+		var word uint64
+		for _, src := range srcs {
+			word |= src.data[idx]
+		}
+		dst.data[idx] = word
+	}
+	dst.clearUnusedBits()
+	return nil
+}
+
+// IntersectAll computes the AND result of all srcs (intersection), storing it in dst. It is safe
+// for dst to be one of srcs. With no srcs, all bits of dst are set. This function will return an
+// error if any of the bitlists is not the same length as dst.
+func IntersectAll(dst *Bitlist64, srcs ...*Bitlist64) error {
+	if err := checkBitlist64Lengths(dst.Len(), srcs); err != nil {
+		return err
+	}
+
+	for idx := range dst.data {
+		word := allBitsSet
+		for _, src := range srcs {
+			word &= src.data[idx]
+		}
+		dst.data[idx] = word
+	}
+	dst.clearUnusedBits()
+	return nil
+}
+
+// UnionCount calculates number of bits set in a union of all srcs, without allocating the result.
+// This function will return an error if the bitlists are not the same length.
+func UnionCount(srcs ...*Bitlist64) (uint64, error) {
+	if len(srcs) == 0 {
+		return 0, nil
+	}
+	if err := checkBitlist64Lengths(srcs[0].Len(), srcs); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for idx := range srcs[0].data {
+		var word uint64
+		for _, src := range srcs {
+			word |= src.data[idx]
+		}
+		// Unused bits in the last word are not part of the bitlists.
+		if idx == len(srcs[0].data)-1 && srcs[0].size%wordSize != 0 {
+			word &= allBitsSet >> (wordSize - srcs[0].size%wordSize)
+		}
+		cnt += bits.OnesCount64(word)
+	}
+	return uint64(cnt), nil
+}
+
+// IntersectCount calculates number of bits set in an intersection of all srcs, without allocating
+// the result. This function will return an error if the bitlists are not the same length.
+func IntersectCount(srcs ...*Bitlist64) (uint64, error) {
+	if len(srcs) == 0 {
+		return 0, nil
+	}
+	if err := checkBitlist64Lengths(srcs[0].Len(), srcs); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for idx := range srcs[0].data {
+		word := allBitsSet
+		for _, src := range srcs {
+			word &= src.data[idx]
+		}
+		// Unused bits in the last word are not part of the bitlists.
+		if idx == len(srcs[0].data)-1 && srcs[0].size%wordSize != 0 {
+			word &= allBitsSet >> (wordSize - srcs[0].size%wordSize)
+		}
+		cnt += bits.OnesCount64(word)
+	}
+	return uint64(cnt), nil
+}
+
+// BitlistUnionAll computes the OR result of all srcs (union), storing it in dst. It is safe for dst
+// to be one of srcs. With no srcs, all bits of dst are cleared. This function will return an error
+// if any of the bitlists is not the same length as dst.
+func BitlistUnionAll(dst Bitlist, srcs ...Bitlist) error {
+	if err := checkBitlistLengths(dst, srcs); err != nil {
+		return err
+	}
+	if len(dst) == 0 {
+		return nil
+	}
+
+	lengthBit := dst.lengthBit()
+	for idx := range dst {
+		var bt byte
+		for _, src := range srcs {
+			bt |= src[idx]
+		}
+		dst[idx] = bt
+	}
+	dst[len(dst)-1] |= lengthBit
+	return nil
+}
+
+// BitlistIntersectAll computes the AND result of all srcs (intersection), storing it in dst. It is
+// safe for dst to be one of srcs. With no srcs, all bits of dst are set. This function will return
+// an error if any of the bitlists is not the same length as dst.
+func BitlistIntersectAll(dst Bitlist, srcs ...Bitlist) error {
+	if err := checkBitlistLengths(dst, srcs); err != nil {
+		return err
+	}
+	if len(dst) == 0 {
+		return nil
+	}
+
+	lengthBit := dst.lengthBit()
+	for idx := range dst {
+		bt := byte(0xff)
+		for _, src := range srcs {
+			bt &= src[idx]
+		}
+		dst[idx] = bt
+	}
+	// Clear the bits above the length bit, then make sure the length bit itself is set.
+	dst[len(dst)-1] = dst[len(dst)-1]&(lengthBit-1) | lengthBit
+	return nil
+}
+
+// BitlistUnionCount calculates number of bits set in a union of all srcs, without allocating the
+// result. This function will return an error if the bitlists are not the same length.
+func BitlistUnionCount(srcs ...Bitlist) (uint64, error) {
+	if len(srcs) == 0 {
+		return 0, nil
+	}
+	if err := checkBitlistLengths(srcs[0], srcs); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for idx := range srcs[0] {
+		var bt byte
+		for _, src := range srcs {
+			bt |= src[idx]
+		}
+		cnt += bits.OnesCount8(bt)
+	}
+	return withoutLengthBit(cnt), nil
+}
+
+// BitlistIntersectCount calculates number of bits set in an intersection of all srcs, without
+// allocating the result. This function will return an error if the bitlists are not the same
+// length.
+func BitlistIntersectCount(srcs ...Bitlist) (uint64, error) {
+	if len(srcs) == 0 {
+		return 0, nil
+	}
+	if err := checkBitlistLengths(srcs[0], srcs); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for idx := range srcs[0] {
+		bt := byte(0xff)
+		for _, src := range srcs {
+			bt &= src[idx]
+		}
+		cnt += bits.OnesCount8(bt)
+	}
+	return withoutLengthBit(cnt), nil
+}
+
+// checkBitlist64Lengths returns an error naming the first of srcs which is not n bits long.
+func checkBitlist64Lengths(n uint64, srcs []*Bitlist64) error {
+	for i, src := range srcs {
+		if src.Len() != n {
+			return fmt.Errorf("%w: srcs[%d] has length %d, wanted %d", ErrBitlistDifferentLength, i, src.Len(), n)
+		}
+	}
+	return nil
+}
+
+// checkBitlistLengths returns an error naming the first of srcs which is not the same length as
+// dst.
+func checkBitlistLengths(dst Bitlist, srcs []Bitlist) error {
+	for i, src := range srcs {
+		if src.Len() != dst.Len() || len(src) != len(dst) {
+			return fmt.Errorf("%w: srcs[%d] has length %d, wanted %d", ErrBitlistDifferentLength, i, src.Len(), dst.Len())
+		}
+	}
+	return nil
+}
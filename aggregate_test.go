@@ -0,0 +1,143 @@
+package bitfield
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnionAll(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, size := range []uint64{0, 1, 7, 64, 65, 300} {
+		for _, numSrcs := range []int{1, 2, 3, 10} {
+			srcs := make([]*Bitlist64, numSrcs)
+			for i := range srcs {
+				srcs[i] = NewBitlist64(size)
+				for j := uint64(0); j < size; j++ {
+					srcs[i].SetBitAt(j, r.Intn(4) == 0)
+				}
+			}
+
+			// Expected results are folded pairwise.
+			union, intersection := srcs[0].Clone(), srcs[0].Clone()
+			for _, src := range srcs[1:] {
+				if err := union.OrInPlace(src); err != nil {
+					t.Fatal(err)
+				}
+				if err := intersection.AndInPlace(src); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			dst := NewBitlist64(size)
+			if err := UnionAll(dst, srcs...); err != nil || !reflect.DeepEqual(dst, union) {
+				t.Errorf("UnionAll(%d x %d bits) = %+v, %v, wanted %+v", numSrcs, size, dst, err, union)
+			}
+			if err := IntersectAll(dst, srcs...); err != nil || !reflect.DeepEqual(dst, intersection) {
+				t.Errorf("IntersectAll(%d x %d bits) = %+v, %v, wanted %+v", numSrcs, size, dst, err, intersection)
+			}
+			if got, err := UnionCount(srcs...); err != nil || got != union.Count() {
+				t.Errorf("UnionCount(%d x %d bits) = %d, %v, wanted %d", numSrcs, size, got, err, union.Count())
+			}
+			if got, err := IntersectCount(srcs...); err != nil || got != intersection.Count() {
+				t.Errorf("IntersectCount(%d x %d bits) = %d, %v, wanted %d", numSrcs, size, got, err, intersection.Count())
+			}
+
+			// Byte backed bitlists must give the same results.
+			bsrcs := make([]Bitlist, numSrcs)
+			for i, src := range srcs {
+				bsrcs[i] = src.ToBitlist()
+			}
+			bdst := NewBitlist(size)
+			if err := BitlistUnionAll(bdst, bsrcs...); err != nil || !bytes.Equal(bdst, union.ToBitlist()) {
+				t.Errorf("BitlistUnionAll(%d x %d bits) = %x, %v, wanted %x", numSrcs, size, bdst, err, union.ToBitlist())
+			}
+			if err := BitlistIntersectAll(bdst, bsrcs...); err != nil || !bytes.Equal(bdst, intersection.ToBitlist()) {
+				t.Errorf("BitlistIntersectAll(%d x %d bits) = %x, %v, wanted %x", numSrcs, size, bdst, err, intersection.ToBitlist())
+			}
+			if got, err := BitlistUnionCount(bsrcs...); err != nil || got != union.Count() {
+				t.Errorf("BitlistUnionCount(%d x %d bits) = %d, %v, wanted %d", numSrcs, size, got, err, union.Count())
+			}
+			if got, err := BitlistIntersectCount(bsrcs...); err != nil || got != intersection.Count() {
+				t.Errorf("BitlistIntersectCount(%d x %d bits) = %d, %v, wanted %d", numSrcs, size, got, err, intersection.Count())
+			}
+		}
+	}
+
+	t.Run("dst is a source", func(t *testing.T) {
+		a := NewBitlist64From([]uint64{0x13})
+		b := NewBitlist64From([]uint64{0x15})
+		if err := UnionAll(a, a, b); err != nil || !reflect.DeepEqual(a, NewBitlist64From([]uint64{0x17})) {
+			t.Errorf("UnionAll(a, a, b) = %+v, %v, wanted 0x17", a, err)
+		}
+		c := Bitlist{0x13, 0x01}
+		if err := BitlistIntersectAll(c, Bitlist{0x15, 0x01}, c); err != nil || !bytes.Equal(c, Bitlist{0x11, 0x01}) {
+			t.Errorf("BitlistIntersectAll(c, ..., c) = %x, %v, wanted %x", c, err, Bitlist{0x11, 0x01})
+		}
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		dst := NewBitlist64From([]uint64{0x13})
+		if err := UnionAll(dst); err != nil || dst.Count() != 0 {
+			t.Errorf("UnionAll() = %+v, %v, wanted empty", dst, err)
+		}
+		if err := IntersectAll(dst); err != nil || dst.Count() != dst.Len() {
+			t.Errorf("IntersectAll() = %+v, %v, wanted all bits set", dst, err)
+		}
+		bdst := Bitlist{0x13}
+		if err := BitlistIntersectAll(bdst); err != nil || !bytes.Equal(bdst, Bitlist{0x1f}) {
+			t.Errorf("BitlistIntersectAll() = %x, %v, wanted %x", bdst, err, Bitlist{0x1f})
+		}
+		if err := BitlistUnionAll(bdst); err != nil || !bytes.Equal(bdst, Bitlist{0x10}) {
+			t.Errorf("BitlistUnionAll() = %x, %v, wanted %x", bdst, err, Bitlist{0x10})
+		}
+		if got, err := UnionCount(); got != 0 || err != nil {
+			t.Errorf("UnionCount() = %d, %v, wanted 0, nil", got, err)
+		}
+	})
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size are not part of the bitlists.
+		a, err := NewBitlist64FromBytes(3, []byte{0xfb})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := &Bitlist64{size: 3, data: []uint64{0xfc}}
+		dst := NewBitlist64(3)
+		if err := UnionAll(dst, a, b); err != nil || !reflect.DeepEqual(dst, &Bitlist64{size: 3, data: []uint64{0x07}}) {
+			t.Errorf("UnionAll(a, b) = %+v, %v, wanted 0x07", dst, err)
+		}
+		if got, err := UnionCount(a, b); got != 3 || err != nil {
+			t.Errorf("UnionCount(a, b) = %d, %v, wanted 3, nil", got, err)
+		}
+		if got, err := IntersectCount(a, b); got != 0 || err != nil {
+			t.Errorf("IntersectCount(a, b) = %d, %v, wanted 0, nil", got, err)
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := NewBitlist64(64), NewBitlist64(128)
+		checks := []error{
+			UnionAll(a, a, a, b),
+			IntersectAll(a, a, a, b),
+			func() error { _, err := UnionCount(a, a, b); return err }(),
+			func() error { _, err := IntersectCount(a, a, b); return err }(),
+			BitlistUnionAll(NewBitlist(8), NewBitlist(8), NewBitlist(8), NewBitlist(9)),
+			BitlistIntersectAll(NewBitlist(8), NewBitlist(8), NewBitlist(8), NewBitlist(9)),
+			func() error { _, err := BitlistUnionCount(NewBitlist(8), NewBitlist(8), NewBitlist(9)); return err }(),
+			func() error { _, err := BitlistIntersectCount(NewBitlist(8), NewBitlist(8), NewBitlist(9)); return err }(),
+		}
+		for _, err := range checks {
+			if !errors.Is(err, ErrBitlistDifferentLength) {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+			}
+			// The offending argument is the third source in every check.
+			if err != nil && !strings.Contains(err.Error(), "srcs[2]") {
+				t.Errorf("Error %q does not name the offending argument srcs[2]", err)
+			}
+		}
+	})
+}
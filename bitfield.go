@@ -15,3 +15,21 @@ type Bitfield interface {
 	// BitIndices returns the indices which have a 1.
 	BitIndices() []int
 }
+
+// StrictBitfield is a Bitfield which also offers accessors reporting out of range indices as
+// errors, instead of ignoring them.
+type StrictBitfield interface {
+	Bitfield
+	// TryBitAt returns true if the bit at the given index is 1, or an error if the index is out of
+	// range.
+	TryBitAt(idx uint64) (bool, error)
+	// TrySetBitAt sets the bit at the given index to val, or returns an error if the index is out of
+	// range.
+	TrySetBitAt(idx uint64, val bool) error
+}
+
+var (
+	_ = StrictBitfield(Bitlist{})
+	_ = StrictBitfield(&Bitlist64{})
+	_ = StrictBitfield(Bitvector8{})
+)
@@ -1,7 +1,9 @@
 package bitfield
 
 import (
+	"iter"
 	"math/bits"
+	"unsafe"
 )
 
 var _ = Bitfield(Bitlist{})
@@ -29,6 +31,33 @@ func NewBitlist(n uint64) Bitlist {
 	return ret
 }
 
+// BitlistFromSSZ returns the bitlist held in the SSZ encoded data, checking that the data is a
+// well formed bitlist of at most limit bits. The returned bitlist shares the underlying array with
+// data.
+func BitlistFromSSZ(data []byte, limit uint64) (Bitlist, error) {
+	if len(data) == 0 {
+		return nil, ErrBitlistMissingDelimiter
+	}
+
+	// The length bit must be the most significant bit of the last byte. If the last byte is zero,
+	// either there is no length bit at all, or there are extra bytes following it.
+	if data[len(data)-1] == 0 {
+		for _, bt := range data {
+			if bt != 0 {
+				return nil, ErrBitlistTrailingBytes
+			}
+		}
+		return nil, ErrBitlistMissingDelimiter
+	}
+
+	b := Bitlist(data)
+	if b.Len() > limit {
+		return nil, ErrBitlistExceedsLimit
+	}
+
+	return b, nil
+}
+
 // BitAt returns the bit value at the given index. If the index requested
 // exceeds the number of bits in the bitlist, then this method returns false.
 func (b Bitlist) BitAt(idx uint64) bool {
@@ -61,6 +90,25 @@ func (b Bitlist) SetBitAt(idx uint64, val bool) {
 
 }
 
+// TryBitAt returns the bit value at the given index. Unlike BitAt, it returns an
+// *ErrIndexOutOfRange if the index exceeds the number of bits in the bitlist.
+func (b Bitlist) TryBitAt(idx uint64) (bool, error) {
+	if n := b.Len(); idx >= n {
+		return false, &ErrIndexOutOfRange{Index: idx, Len: n}
+	}
+	return b.BitAt(idx), nil
+}
+
+// TrySetBitAt will set the bit at the given index to the given value. Unlike SetBitAt, it returns
+// an *ErrIndexOutOfRange if the index exceeds the number of bits in the bitlist.
+func (b Bitlist) TrySetBitAt(idx uint64, val bool) error {
+	if n := b.Len(); idx >= n {
+		return &ErrIndexOutOfRange{Index: idx, Len: n}
+	}
+	b.SetBitAt(idx, val)
+	return nil
+}
+
 // Len of the bitlist returns the number of bits available in the underlying
 // byte array.
 func (b Bitlist) Len() uint64 {
@@ -140,6 +188,22 @@ func (b Bitlist) ToBitlist64() (*Bitlist64, error) {
 	return NewBitlist64FromBytes(b.Len(), b.BytesNoTrim())
 }
 
+// HashTreeRoot returns the SSZ hash tree root of the bitlist, for a bitlist type holding at most
+// limit bits. This method will return an error if the bitlist is longer than limit.
+func (b Bitlist) HashTreeRoot(limit uint64) ([32]byte, error) {
+	n := b.Len()
+	if n > limit {
+		return [32]byte{}, ErrBitlistExceedsLimit
+	}
+	return bitlistHashTreeRoot(b, n, limit), nil
+}
+
+// Multiproof returns a merkle multiproof that the bits at the given indices are set, for a bitlist
+// type holding at most limit bits. The proof can be checked with VerifyBitlistMultiproof.
+func (b Bitlist) Multiproof(limit uint64, bitIndices []uint64) (*Multiproof, error) {
+	return bitlistMultiproof(b, b.Len(), limit, bitIndices)
+}
+
 // Count returns the number of 1s in the bitlist.
 func (b Bitlist) Count() uint64 {
 	c := 0
@@ -155,6 +219,18 @@ func (b Bitlist) Count() uint64 {
 	return uint64(c)
 }
 
+// Rank returns the number of bits set to 1 at indices lower than idx. If idx exceeds the length of
+// the bitlist, the number of all bits set is returned.
+func (b Bitlist) Rank(idx uint64) uint64 {
+	return rankBits(b, b.Len(), idx)
+}
+
+// Select returns the index of the k-th (counting from zero) bit set to 1, so that Rank of the
+// returned index is k. The second return value is false if fewer than k+1 bits are set.
+func (b Bitlist) Select(k uint64) (uint64, bool) {
+	return selectBits(b, b.Len(), k)
+}
+
 // Contains returns true if the bitlist contains all of the bits from the provided argument
 // bitlist. This method will return an error if bitlists are not the same length.
 func (b Bitlist) Contains(c Bitlist) (bool, error) {
@@ -231,6 +307,21 @@ func (b Bitlist) NoAllocOr(c, ret Bitlist) error {
 	return nil
 }
 
+// OrCount calculates number of bits set in a union of two bitfields.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) OrCount(c Bitlist) (uint64, error) {
+	if b.Len() != c.Len() {
+		return 0, ErrBitlistDifferentLength
+	}
+
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8(b[i] | c[i])
+	}
+
+	return withoutLengthBit(cnt), nil
+}
+
 // And returns the AND result of the two bitfields. This method will return an error if the bitlists are not the same length.
 func (b Bitlist) And(c Bitlist) (Bitlist, error) {
 	if b.Len() != c.Len() {
@@ -245,52 +336,255 @@ func (b Bitlist) And(c Bitlist) (Bitlist, error) {
 	return ret, nil
 }
 
+// NoAllocAnd computes the AND result of the two bitfields (intersection).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) NoAllocAnd(c, ret Bitlist) error {
+	if b.Len() != c.Len() || b.Len() != ret.Len() {
+		return ErrBitlistDifferentLength
+	}
+
+	for idx, word := range b {
+		ret[idx] = word & c[idx]
+	}
+	return nil
+}
+
+// AndCount calculates number of bits set in an intersection of two bitfields.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) AndCount(c Bitlist) (uint64, error) {
+	if b.Len() != c.Len() {
+		return 0, ErrBitlistDifferentLength
+	}
+
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8(b[i] & c[i])
+	}
+
+	return withoutLengthBit(cnt), nil
+}
+
 // Xor returns the XOR result of the two bitfields. This method will return an error if the bitlists are not the same length.
 func (b Bitlist) Xor(c Bitlist) (Bitlist, error) {
 	if b.Len() != c.Len() {
 		return nil, ErrBitlistDifferentLength
 	}
 
-	// Process all bytes but the last.
 	ret := make([]byte, len(b))
-	for i := 0; i < len(b)-1; i++ {
+	b.xor(c, ret)
+
+	return ret, nil
+}
+
+// NoAllocXor computes the XOR result of the two bitfields (symmetric difference).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) NoAllocXor(c, ret Bitlist) error {
+	if b.Len() != c.Len() || b.Len() != ret.Len() || len(b) != len(ret) {
+		return ErrBitlistDifferentLength
+	}
+
+	b.xor(c, ret)
+	return nil
+}
+
+// XorCount calculates number of bits set in a symmetric difference of two bitfields.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) XorCount(c Bitlist) (uint64, error) {
+	if b.Len() != c.Len() {
+		return 0, ErrBitlistDifferentLength
+	}
+
+	// The length bits are at the same position in both bitlists, so they cancel out.
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8(b[i] ^ c[i])
+	}
+
+	return uint64(cnt), nil
+}
+
+// xor writes b XOR c into ret, restoring the length bit cancelled out by c's own length bit. Any of
+// the bitlists may alias each other.
+func (b Bitlist) xor(c, ret Bitlist) {
+	if len(b) == 0 {
+		return
+	}
+	lengthBit := b.lengthBit()
+	for i := 0; i < len(b); i++ {
 		ret[i] = b[i] ^ c[i]
 	}
+	ret[len(ret)-1] |= lengthBit
+}
 
-	// For the last byte, process only bits smaller than the length bit.
-	ret[len(b)-1] = b[len(b)-1]
-	msb := uint8(bits.Len8(b[len(b)-1])) - 1
-	if msb > 0 {
-		mask := uint8(0xff >> (8 - msb))
-		ret[len(b)-1] = (b[len(b)-1] ^ c[len(b)-1]) & mask
-		ret[len(b)-1] |= uint8(1 << msb)
+// AndNot returns the AND NOT result of the two bitfields (difference), i.e. the bits set in b but
+// not in c. This method will return an error if the bitlists are not the same length.
+func (b Bitlist) AndNot(c Bitlist) (Bitlist, error) {
+	if b.Len() != c.Len() {
+		return nil, ErrBitlistDifferentLength
 	}
 
+	ret := make([]byte, len(b))
+	b.andNot(c, ret)
+
 	return ret, nil
 }
 
+// NoAllocAndNot computes the AND NOT result of the two bitfields (difference).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) NoAllocAndNot(c, ret Bitlist) error {
+	if b.Len() != c.Len() || b.Len() != ret.Len() || len(b) != len(ret) {
+		return ErrBitlistDifferentLength
+	}
+
+	b.andNot(c, ret)
+	return nil
+}
+
+// AndNotCount calculates number of bits set in a difference of two bitfields.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) AndNotCount(c Bitlist) (uint64, error) {
+	if b.Len() != c.Len() {
+		return 0, ErrBitlistDifferentLength
+	}
+
+	// The length bits are at the same position in both bitlists, so they cancel out.
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8(b[i] &^ c[i])
+	}
+
+	return uint64(cnt), nil
+}
+
+// andNot writes b AND NOT c into ret, restoring the length bit cleared by c's own length bit. Any
+// of the bitlists may alias each other.
+func (b Bitlist) andNot(c, ret Bitlist) {
+	if len(b) == 0 {
+		return
+	}
+	lengthBit := b.lengthBit()
+	for i := 0; i < len(b); i++ {
+		ret[i] = b[i] &^ c[i]
+	}
+	ret[len(ret)-1] |= lengthBit
+}
+
 // Not returns the NOT result of the bitfield.
 func (b Bitlist) Not() Bitlist {
 	if b.Len() == 0 {
 		return b
 	}
 
-	// Process all bytes but the last.
 	ret := make([]byte, len(b))
+	b.not(ret)
+
+	return ret
+}
+
+// NoAllocNot computes the NOT result of the bitfield (complement).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitlists are not the same length.
+func (b Bitlist) NoAllocNot(ret Bitlist) error {
+	if b.Len() != ret.Len() || len(b) != len(ret) {
+		return ErrBitlistDifferentLength
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+
+	b.not(ret)
+	return nil
+}
+
+// not writes NOT b into ret, keeping the bits above the length bit cleared. The bitlists may alias
+// each other.
+func (b Bitlist) not(ret Bitlist) {
+	// Process all bytes but the last.
 	for i := 0; i < len(b)-1; i++ {
 		ret[i] = ^b[i]
 	}
 
 	// For the last byte, process only bits smaller than the length bit.
-	ret[len(b)-1] = b[len(b)-1]
-	msb := uint8(bits.Len8(b[len(b)-1])) - 1
-	if msb > 0 {
-		mask := uint8(0xff >> (8 - msb))
-		ret[len(b)-1] = (^b[len(b)-1]) & mask
-		ret[len(b)-1] |= uint8(1 << msb)
+	last := b[len(b)-1]
+	msb := uint8(bits.Len8(last)) - 1
+	ret[len(b)-1] = (^last)&uint8(0xff>>(8-msb)) | uint8(1<<msb)
+}
+
+// lengthBit returns the last byte of the bitlist with only the length bit set.
+func (b Bitlist) lengthBit() byte {
+	last := b[len(b)-1]
+	if last == 0 {
+		return 0
+	}
+	return 1 << (bits.Len8(last) - 1)
+}
+
+// withoutLengthBit removes the length bit from the number of bits set in a bitlist.
+func withoutLengthBit(cnt int) uint64 {
+	if cnt > 0 {
+		cnt--
 	}
+	return uint64(cnt)
+}
 
-	return ret
+// OrInPlace computes the OR result of the two bitfields (union), storing it in b. It is safe for b
+// and c to share the same storage. This method will return an error if the bitlists are not the
+// same length.
+func (b Bitlist) OrInPlace(c Bitlist) error {
+	return b.NoAllocOr(c, b)
+}
+
+// AndInPlace computes the AND result of the two bitfields (intersection), storing it in b. It is
+// safe for b and c to share the same storage. This method will return an error if the bitlists are
+// not the same length.
+func (b Bitlist) AndInPlace(c Bitlist) error {
+	return b.NoAllocAnd(c, b)
+}
+
+// XorInPlace computes the XOR result of the two bitfields (symmetric difference), storing it in b.
+// It is safe for b and c to share the same storage. This method will return an error if the
+// bitlists are not the same length.
+func (b Bitlist) XorInPlace(c Bitlist) error {
+	return b.NoAllocXor(c, b)
+}
+
+// AndNotInPlace computes the AND NOT result of the two bitfields (difference), storing it in b. It
+// is safe for b and c to share the same storage. This method will return an error if the bitlists
+// are not the same length.
+func (b Bitlist) AndNotInPlace(c Bitlist) error {
+	return b.NoAllocAndNot(c, b)
+}
+
+// NotInPlace computes the NOT result of the bitfield (complement), storing it in b.
+func (b Bitlist) NotInPlace() {
+	if b.Len() == 0 {
+		return
+	}
+	b.not(b)
+}
+
+// Shift bitlist by i. If i >= 0, perform left shift, otherwise right shift. A left shift moves the
+// bit at index k to index k+i, matching BitAt indexing, and bits shifted past either end of the
+// bitlist are dropped. The length bit is left in place.
+func (b Bitlist) Shift(i int) {
+	shiftBits(b, b.Len(), i)
+}
+
+// RotateLeft rotates bitlist by i, moving the bit at index k to index (k+i) mod Len(). A negative i
+// rotates to the right.
+func (b Bitlist) RotateLeft(i int) {
+	n := b.Len()
+	rotateBits(b, n, rotation(n, i))
+}
+
+// RotateRight rotates bitlist by i, moving the bit at index k to index (k-i) mod Len(). A negative i
+// rotates to the left.
+func (b Bitlist) RotateRight(i int) {
+	n := b.Len()
+	rotateBits(b, n, n-rotation(n, i))
 }
 
 // BitIndices returns the list of indices that are set to 1.
@@ -312,3 +606,212 @@ func (b Bitlist) BitIndices() []int {
 
 	return indices
 }
+
+// NoAllocBitIndices returns list of bit indexes of bitlist where value is set to true.
+// No allocation happens inside the function, so number of returned indexes is capped by the length
+// of the ret param.
+//
+// Expected usage pattern:
+//
+// b := NewBitlist(n)
+// indices := make([]int, b.Count())
+// b.NoAllocBitIndices(indices)
+func (b Bitlist) NoAllocBitIndices(ret []int) {
+	n := b.Len()
+	k := 0
+	for i := uint64(0); i < (n+7)>>3; i++ {
+		for bt := maskedByte(b, n, i, false); bt != 0; bt &= bt - 1 {
+			if k == len(ret) {
+				return
+			}
+			ret[k] = int(i<<3) + bits.TrailingZeros8(bt)
+			k++
+		}
+	}
+}
+
+// Clone safely copies a given bitlist.
+func (b Bitlist) Clone() Bitlist {
+	if b == nil {
+		return nil
+	}
+	c := make(Bitlist, len(b))
+	copy(c, b)
+	return c
+}
+
+// Resize returns the bitlist resized to n bits, moving the length bit. Bits below both lengths are
+// kept, and bits added by growing the bitlist are 0. Like the builtin append, the result may share
+// the underlying array with b, so b must not be used afterwards.
+func (b Bitlist) Resize(n uint64) Bitlist {
+	if len(b) > 0 {
+		b[len(b)-1] &^= b.lengthBit()
+	}
+
+	size := int(n>>3) + 1
+	if size > len(b) {
+		b = append(b, make([]byte, size-len(b))...)
+	} else {
+		b = b[:size]
+		// Clear the bits past the new length, which were kept when shrinking.
+		b[size-1] &= 0xff >> (8 - n%8)
+	}
+	b[size-1] |= 1 << (n % 8)
+	return b
+}
+
+// Truncate returns the bitlist shortened to n bits, or b itself if it has no more than n bits.
+// Like the builtin append, the result may share the underlying array with b, so b must not be used
+// afterwards.
+func (b Bitlist) Truncate(n uint64) Bitlist {
+	if n >= b.Len() {
+		return b
+	}
+	return b.Resize(n)
+}
+
+// Append returns the bitlist with the given bit added after its last bit. Like the builtin append,
+// the result may share the underlying array with b, so b must not be used afterwards.
+func (b Bitlist) Append(val bool) Bitlist {
+	n := b.Len()
+	b = b.Resize(n + 1)
+	b.SetBitAt(n, val)
+	return b
+}
+
+// AppendBits returns the bitlist with all bits of other added after its last bit. Like the builtin
+// append, the result may share the underlying array with b, so b must not be used afterwards.
+func (b Bitlist) AppendBits(other Bitlist) Bitlist {
+	if overlaps(b[:cap(b)], other) {
+		other = other.Clone()
+	}
+
+	n, otherLen := b.Len(), other.Len()
+	b = b.Resize(n + otherLen)
+	base, offset := n>>3, n%8
+	for i := uint64(0); i < (otherLen+7)>>3; i++ {
+		bt := maskedByte(other, otherLen, i, false)
+		b[base+i] |= bt << offset
+		if offset > 0 && base+i+1 < uint64(len(b)) {
+			b[base+i+1] |= bt >> (8 - offset)
+		}
+	}
+	return b
+}
+
+// overlaps reports whether a and b share any bytes of their underlying arrays.
+func overlaps(a, b []byte) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	aStart, bStart := uintptr(unsafe.Pointer(&a[0])), uintptr(unsafe.Pointer(&b[0]))
+	return aStart < bStart+uintptr(len(b)) && bStart < aStart+uintptr(len(a))
+}
+
+// Slice returns a new bitlist holding the bits of b in the range [from, to). This method will
+// return an error if the range does not lie within the bitlist.
+func (b Bitlist) Slice(from, to uint64) (Bitlist, error) {
+	if err := checkRange(from, to, b.Len()); err != nil {
+		return nil, err
+	}
+
+	n := to - from
+	ret := NewBitlist(n)
+	base, offset := from>>3, from%8
+	for i := uint64(0); i < (n+7)>>3; i++ {
+		bt := b[base+i] >> offset
+		if offset > 0 && base+i+1 < uint64(len(b)) {
+			bt |= b[base+i+1] << (8 - offset)
+		}
+		ret[i] = bt
+	}
+	// Clear the bits past to, then restore the length bit.
+	ret[n>>3] &= 0xff >> (8 - n%8)
+	ret[n>>3] |= 1 << (n % 8)
+	return ret, nil
+}
+
+// Concat returns a new bitlist holding the bits of b followed by the bits of each of others.
+func (b Bitlist) Concat(others ...Bitlist) Bitlist {
+	n := b.Len()
+	for _, other := range others {
+		n += other.Len()
+	}
+
+	ret := make(Bitlist, 0, n>>3+1).AppendBits(b)
+	for _, other := range others {
+		ret = ret.AppendBits(other)
+	}
+	return ret
+}
+
+// SetRange sets all bits in the range [from, to) to the given value. This method will return an
+// error, leaving the bitlist unchanged, if the range does not lie within the bitlist.
+func (b Bitlist) SetRange(from, to uint64, val bool) error {
+	if err := checkRange(from, to, b.Len()); err != nil {
+		return err
+	}
+	setRangeBits(b, from, to, val)
+	return nil
+}
+
+// ClearRange sets all bits in the range [from, to) to 0. This method will return an error, leaving
+// the bitlist unchanged, if the range does not lie within the bitlist.
+func (b Bitlist) ClearRange(from, to uint64) error {
+	return b.SetRange(from, to, false)
+}
+
+// FlipRange inverts all bits in the range [from, to). This method will return an error, leaving
+// the bitlist unchanged, if the range does not lie within the bitlist.
+func (b Bitlist) FlipRange(from, to uint64) error {
+	if err := checkRange(from, to, b.Len()); err != nil {
+		return err
+	}
+	flipRangeBits(b, from, to)
+	return nil
+}
+
+// CountRange returns the number of bits set to 1 in the range [from, to). This method will return
+// an error if the range does not lie within the bitlist.
+func (b Bitlist) CountRange(from, to uint64) (uint64, error) {
+	if err := checkRange(from, to, b.Len()); err != nil {
+		return 0, err
+	}
+	return countRangeBits(b, from, to), nil
+}
+
+// All returns an iterator over the indices which are set to 1, in ascending order.
+func (b Bitlist) All() iter.Seq[uint64] {
+	return bitsSeq(b, b.Len(), false)
+}
+
+// AllUnset returns an iterator over the indices which are set to 0, in ascending order.
+func (b Bitlist) AllUnset() iter.Seq[uint64] {
+	return bitsSeq(b, b.Len(), true)
+}
+
+// NextSet returns the lowest index at or after from of a bit set to 1. The second return value is
+// false if there is no such bit. The length bit is never reported.
+func (b Bitlist) NextSet(from uint64) (uint64, bool) {
+	return nextBit(b, b.Len(), from, false)
+}
+
+// NextClear returns the lowest index at or after from of a bit set to 0. The second return value
+// is false if there is no such bit.
+func (b Bitlist) NextClear(from uint64) (uint64, bool) {
+	return nextBit(b, b.Len(), from, true)
+}
+
+// PrevSet returns the highest index at or before from of a bit set to 1. If from exceeds the
+// length of the bitlist, the search starts from the last bit. The second return value is false if
+// there is no such bit. The length bit is never reported.
+func (b Bitlist) PrevSet(from uint64) (uint64, bool) {
+	return prevBit(b, b.Len(), from, false)
+}
+
+// PrevClear returns the highest index at or before from of a bit set to 0. If from exceeds the
+// length of the bitlist, the search starts from the last bit. The second return value is false if
+// there is no such bit.
+func (b Bitlist) PrevClear(from uint64) (uint64, bool) {
+	return prevBit(b, b.Len(), from, true)
+}
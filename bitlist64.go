@@ -3,6 +3,7 @@ package bitfield
 import (
 	"encoding/binary"
 	"fmt"
+	"iter"
 	"math/bits"
 )
 
@@ -68,6 +69,16 @@ func NewBitlist64FromBytes(n uint64, b []byte) (*Bitlist64, error) {
 	}, nil
 }
 
+// Bitlist64FromSSZ creates a new bitlist from SSZ encoded data, checking that the data is a well
+// formed bitlist of at most limit bits. See BitlistFromSSZ for the errors returned.
+func Bitlist64FromSSZ(data []byte, limit uint64) (*Bitlist64, error) {
+	b, err := BitlistFromSSZ(data, limit)
+	if err != nil {
+		return nil, err
+	}
+	return b.ToBitlist64()
+}
+
 // BitAt returns the bit value at the given index. If the index requested
 // exceeds the number of bits in the bitlist, then this method returns false.
 func (b *Bitlist64) BitAt(idx uint64) bool {
@@ -96,6 +107,25 @@ func (b *Bitlist64) SetBitAt(idx uint64, val bool) {
 	}
 }
 
+// TryBitAt returns the bit value at the given index. Unlike BitAt, it returns an
+// *ErrIndexOutOfRange if the index exceeds the number of bits in the bitlist.
+func (b *Bitlist64) TryBitAt(idx uint64) (bool, error) {
+	if idx >= b.size {
+		return false, &ErrIndexOutOfRange{Index: idx, Len: b.size}
+	}
+	return b.BitAt(idx), nil
+}
+
+// TrySetBitAt will set the bit at the given index to the given value. Unlike SetBitAt, it returns
+// an *ErrIndexOutOfRange if the index exceeds the number of bits in the bitlist.
+func (b *Bitlist64) TrySetBitAt(idx uint64, val bool) error {
+	if idx >= b.size {
+		return &ErrIndexOutOfRange{Index: idx, Len: b.size}
+	}
+	b.SetBitAt(idx, val)
+	return nil
+}
+
 // Len returns the number of bits in a bitlist (note that underlying array can be bigger).
 func (b *Bitlist64) Len() uint64 {
 	return b.size
@@ -162,6 +192,29 @@ func (b *Bitlist64) ToBitlist() Bitlist {
 	return ret
 }
 
+// masked returns a copy of the bitlist without any unused bits set past the size.
+func (b *Bitlist64) masked() *Bitlist64 {
+	c := b.Clone()
+	c.clearUnusedBits()
+	return c
+}
+
+// HashTreeRoot returns the SSZ hash tree root of the bitlist, for a bitlist type holding at most
+// limit bits. This method will return an error if the bitlist is longer than limit.
+func (b *Bitlist64) HashTreeRoot(limit uint64) ([32]byte, error) {
+	if b.size > limit {
+		return [32]byte{}, ErrBitlistExceedsLimit
+	}
+
+	return bitlistHashTreeRoot(b.littleEndianBytes(), b.size, limit), nil
+}
+
+// Multiproof returns a merkle multiproof that the bits at the given indices are set, for a bitlist
+// type holding at most limit bits. The proof can be checked with VerifyBitlistMultiproof.
+func (b *Bitlist64) Multiproof(limit uint64, bitIndices []uint64) (*Multiproof, error) {
+	return bitlistMultiproof(b.littleEndianBytes(), b.size, limit, bitIndices)
+}
+
 // Count returns the number of 1s in the bitlist.
 func (b *Bitlist64) Count() uint64 {
 	c := 0
@@ -172,6 +225,37 @@ func (b *Bitlist64) Count() uint64 {
 	return uint64(c)
 }
 
+// Rank returns the number of bits set to 1 at indices lower than idx. If idx exceeds the length of
+// the bitlist, the number of all bits set is returned. See RankSelectIndex for faster queries on
+// large bitlists.
+func (b *Bitlist64) Rank(idx uint64) uint64 {
+	if idx > b.size {
+		idx = b.size
+	}
+	c := 0
+	for _, word := range b.data[:idx>>wordSizeLog2] {
+		c += bits.OnesCount64(word)
+	}
+	if idx%wordSize != 0 {
+		c += bits.OnesCount64(b.data[idx>>wordSizeLog2] & (allBitsSet >> (wordSize - idx%wordSize)))
+	}
+	return uint64(c)
+}
+
+// Select returns the index of the k-th (counting from zero) bit set to 1, so that Rank of the
+// returned index is k. The second return value is false if fewer than k+1 bits are set.
+func (b *Bitlist64) Select(k uint64) (uint64, bool) {
+	for idx := range b.data {
+		word := b.maskedWord(uint64(idx), false)
+		c := uint64(bits.OnesCount64(word))
+		if k < c {
+			return uint64(idx)<<wordSizeLog2 + uint64(selectInWord(word, int(k))), true
+		}
+		k -= c
+	}
+	return 0, false
+}
+
 // Contains returns true if the bitlist contains all of the bits from the provided argument
 // bitlist i.e. if `b` is a superset of `c`.
 // This method will return an error if bitlists are not the same length.
@@ -342,6 +426,49 @@ func (b *Bitlist64) XorCount(c *Bitlist64) (uint64, error) {
 	return uint64(cnt), nil
 }
 
+// AndNot returns the AND NOT result of the two bitfields (difference), i.e. the bits set in b but
+// not in c. This method will return an error if the bitlists are not the same length.
+func (b *Bitlist64) AndNot(c *Bitlist64) (*Bitlist64, error) {
+	if b.Len() != c.Len() {
+		return nil, ErrBitlistDifferentLength
+	}
+
+	ret := b.Clone()
+	b.NoAllocAndNot(c, ret)
+
+	return ret, nil
+}
+
+// NoAllocAndNot computes the AND NOT result of the two bitfields (difference).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitlists are not the same length.
+func (b *Bitlist64) NoAllocAndNot(c, ret *Bitlist64) error {
+	if b.Len() != c.Len() || b.Len() != ret.Len() {
+		return ErrBitlistDifferentLength
+	}
+
+	for idx, word := range b.data {
+		ret.data[idx] = word &^ c.data[idx]
+	}
+	ret.clearUnusedBits()
+	return nil
+}
+
+// AndNotCount calculates number of bits set in a difference of two bitfields.
+// This method will return an error if the bitlists are not the same length.
+func (b *Bitlist64) AndNotCount(c *Bitlist64) (uint64, error) {
+	if b.Len() != c.Len() {
+		return 0, ErrBitlistDifferentLength
+	}
+
+	var cnt int
+	for idx := range b.data {
+		cnt += bits.OnesCount64(b.maskedWord(uint64(idx), false) &^ c.data[idx])
+	}
+
+	return uint64(cnt), nil
+}
+
 // Not returns the NOT result of the bitfield (complement).
 func (b *Bitlist64) Not() *Bitlist64 {
 	if b.Len() == 0 {
@@ -367,6 +494,62 @@ func (b *Bitlist64) NoAllocNot(ret *Bitlist64) {
 	ret.clearUnusedBits()
 }
 
+// OrInPlace computes the OR result of the two bitfields (union), storing it in b. It is safe for b
+// and c to share the same storage. This method will return an error if the bitlists are not the
+// same length.
+func (b *Bitlist64) OrInPlace(c *Bitlist64) error {
+	return b.NoAllocOr(c, b)
+}
+
+// AndInPlace computes the AND result of the two bitfields (intersection), storing it in b. It is
+// safe for b and c to share the same storage. This method will return an error if the bitlists are
+// not the same length.
+func (b *Bitlist64) AndInPlace(c *Bitlist64) error {
+	return b.NoAllocAnd(c, b)
+}
+
+// XorInPlace computes the XOR result of the two bitfields (symmetric difference), storing it in b.
+// It is safe for b and c to share the same storage. This method will return an error if the
+// bitlists are not the same length.
+func (b *Bitlist64) XorInPlace(c *Bitlist64) error {
+	return b.NoAllocXor(c, b)
+}
+
+// AndNotInPlace computes the AND NOT result of the two bitfields (difference), storing it in b. It
+// is safe for b and c to share the same storage. This method will return an error if the bitlists
+// are not the same length.
+func (b *Bitlist64) AndNotInPlace(c *Bitlist64) error {
+	return b.NoAllocAndNot(c, b)
+}
+
+// NotInPlace computes the NOT result of the bitfield (complement), storing it in b.
+func (b *Bitlist64) NotInPlace() {
+	b.NoAllocNot(b)
+}
+
+// Shift bitlist by i. If i >= 0, perform left shift, otherwise right shift. A left shift moves the
+// bit at index k to index k+i, matching BitAt indexing, and bits shifted past either end of the
+// bitlist are dropped.
+func (b *Bitlist64) Shift(i int) {
+	if i >= 0 {
+		b.shiftUp(uint64(i))
+	} else {
+		b.shiftDown(uint64(-(i + 1)) + 1)
+	}
+}
+
+// RotateLeft rotates bitlist by i, moving the bit at index k to index (k+i) mod Len(). A negative i
+// rotates to the right.
+func (b *Bitlist64) RotateLeft(i int) {
+	b.rotate(rotation(b.size, i))
+}
+
+// RotateRight rotates bitlist by i, moving the bit at index k to index (k-i) mod Len(). A negative i
+// rotates to the left.
+func (b *Bitlist64) RotateRight(i int) {
+	b.rotate(b.size - rotation(b.size, i))
+}
+
 // BitIndices returns list of bit indexes of bitlist where value is set to true.
 func (b *Bitlist64) BitIndices() []int {
 	indices := make([]int, b.Count())
@@ -411,6 +594,129 @@ func (b *Bitlist64) NoAllocBitIndices(ret []int) {
 	}
 }
 
+// All returns an iterator over the bit indexes of bitlist where value is set to true, in ascending
+// order. As with NoAllocBitIndices, words are scanned with bits.TrailingZeros64, so only set bits
+// are visited and nothing is allocated per bit.
+func (b *Bitlist64) All() iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		for idx := range b.data {
+			// Unused bits in the last word are not part of the bitlist.
+			word := b.maskedWord(uint64(idx), false)
+			for word != 0 {
+				if !yield(uint64(idx)<<wordSizeLog2 + uint64(bits.TrailingZeros64(word))) {
+					return
+				}
+				// Clear less significant (rightmost) non-zero bit.
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// AllUnset returns an iterator over the bit indexes of bitlist where value is set to false, in
+// ascending order.
+func (b *Bitlist64) AllUnset() iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		for idx := range b.data {
+			// Unused bits in the last word are not part of the bitlist.
+			word := b.maskedWord(uint64(idx), true)
+			for word != 0 {
+				if !yield(uint64(idx)<<wordSizeLog2 + uint64(bits.TrailingZeros64(word))) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// NextSet returns the lowest index at or after from of a bit set to 1. The second return value is
+// false if there is no such bit.
+func (b *Bitlist64) NextSet(from uint64) (uint64, bool) {
+	return b.nextBit(from, false)
+}
+
+// NextClear returns the lowest index at or after from of a bit set to 0. The second return value
+// is false if there is no such bit.
+func (b *Bitlist64) NextClear(from uint64) (uint64, bool) {
+	return b.nextBit(from, true)
+}
+
+// PrevSet returns the highest index at or before from of a bit set to 1. If from exceeds the
+// length of the bitlist, the search starts from the last bit. The second return value is false if
+// there is no such bit.
+func (b *Bitlist64) PrevSet(from uint64) (uint64, bool) {
+	return b.prevBit(from, false)
+}
+
+// PrevClear returns the highest index at or before from of a bit set to 0. If from exceeds the
+// length of the bitlist, the search starts from the last bit. The second return value is false if
+// there is no such bit.
+func (b *Bitlist64) PrevClear(from uint64) (uint64, bool) {
+	return b.prevBit(from, true)
+}
+
+// nextBit scans words from the one holding from towards the end of the bitlist.
+func (b *Bitlist64) nextBit(from uint64, unset bool) (uint64, bool) {
+	if from >= b.size {
+		return 0, false
+	}
+	idx := from >> wordSizeLog2
+	word := b.maskedWord(idx, unset) & (allBitsSet << (from % wordSize))
+	for word == 0 {
+		idx++
+		if idx >= uint64(len(b.data)) {
+			return 0, false
+		}
+		word = b.maskedWord(idx, unset)
+	}
+	return idx<<wordSizeLog2 + uint64(bits.TrailingZeros64(word)), true
+}
+
+// prevBit scans words from the one holding from towards the start of the bitlist.
+func (b *Bitlist64) prevBit(from uint64, unset bool) (uint64, bool) {
+	if b.size == 0 {
+		return 0, false
+	}
+	if from >= b.size {
+		from = b.size - 1
+	}
+	idx := from >> wordSizeLog2
+	word := b.maskedWord(idx, unset) & (allBitsSet >> (wordSize - 1 - from%wordSize))
+	for word == 0 {
+		if idx == 0 {
+			return 0, false
+		}
+		idx--
+		word = b.maskedWord(idx, unset)
+	}
+	return idx<<wordSizeLog2 + wordSize - 1 - uint64(bits.LeadingZeros64(word)), true
+}
+
+// maskedWord returns the word at idx, inverted if unset is true, with unused bits cleared.
+func (b *Bitlist64) maskedWord(idx uint64, unset bool) uint64 {
+	word := b.data[idx]
+	if unset {
+		word = ^word
+	}
+	if idx == uint64(len(b.data))-1 && b.size%wordSize != 0 {
+		word &= allBitsSet >> (wordSize - b.size%wordSize)
+	}
+	return word
+}
+
+// rangeWordMask returns the bits of word idx which lie in the range [from, to).
+func rangeWordMask(idx, from, to uint64) uint64 {
+	lo, mask := idx<<wordSizeLog2, allBitsSet
+	if from > lo {
+		mask <<= from - lo
+	}
+	if to < lo+wordSize {
+		mask &= allBitsSet >> (lo + wordSize - to)
+	}
+	return mask
+}
+
 // Clone safely copies a given bitlist.
 func (b *Bitlist64) Clone() *Bitlist64 {
 	c := NewBitlist64(b.size)
@@ -420,11 +726,232 @@ func (b *Bitlist64) Clone() *Bitlist64 {
 	return c
 }
 
+// Resize changes the number of bits in the bitlist to n. Bits below both lengths are kept, and bits
+// added by growing the bitlist are 0.
+func (b *Bitlist64) Resize(n uint64) {
+	if b.size > 0 {
+		// Make sure no unused bits become part of the bitlist.
+		b.clearUnusedBits()
+	}
+
+	size := numWordsRequired(n)
+	if size > len(b.data) {
+		b.data = append(b.data, make([]uint64, size-len(b.data))...)
+	} else {
+		clear(b.data[size:])
+		b.data = b.data[:size]
+	}
+	b.size = n
+	if n > 0 {
+		b.clearUnusedBits()
+	}
+}
+
+// Truncate shortens the bitlist to n bits. It does nothing if the bitlist has no more than n bits.
+func (b *Bitlist64) Truncate(n uint64) {
+	if n < b.size {
+		b.Resize(n)
+	}
+}
+
+// Append adds the given bit after the last bit of the bitlist.
+func (b *Bitlist64) Append(val bool) {
+	b.Resize(b.size + 1)
+	b.SetBitAt(b.size-1, val)
+}
+
+// AppendBits adds all bits of other after the last bit of the bitlist. It is safe for b and other
+// to be the same bitlist.
+func (b *Bitlist64) AppendBits(other *Bitlist64) {
+	if other == b {
+		other = other.Clone()
+	}
+
+	n := b.size
+	b.Resize(n + other.size)
+	base, offset := n>>wordSizeLog2, n%wordSize
+	for idx := uint64(0); idx < uint64(numWordsRequired(other.size)); idx++ {
+		word := other.maskedWord(idx, false)
+		b.data[base+idx] |= word << offset
+		if offset > 0 && base+idx+1 < uint64(len(b.data)) {
+			b.data[base+idx+1] |= word >> (wordSize - offset)
+		}
+	}
+}
+
+// Slice returns a new bitlist holding the bits of b in the range [from, to). This method will
+// return an error if the range does not lie within the bitlist.
+func (b *Bitlist64) Slice(from, to uint64) (*Bitlist64, error) {
+	if err := checkRange(from, to, b.size); err != nil {
+		return nil, err
+	}
+
+	ret := NewBitlist64(to - from)
+	base, offset := from>>wordSizeLog2, from%wordSize
+	for idx := range ret.data {
+		src := base + uint64(idx)
+		word := b.data[src] >> offset
+		if offset > 0 && src+1 < uint64(len(b.data)) {
+			word |= b.data[src+1] << (wordSize - offset)
+		}
+		ret.data[idx] = word
+	}
+	ret.clearUnusedBits()
+	return ret, nil
+}
+
+// Concat returns a new bitlist holding the bits of b followed by the bits of each of others.
+func (b *Bitlist64) Concat(others ...*Bitlist64) *Bitlist64 {
+	n := b.size
+	for _, other := range others {
+		n += other.size
+	}
+
+	ret := &Bitlist64{data: make([]uint64, 0, numWordsRequired(n))}
+	ret.AppendBits(b)
+	for _, other := range others {
+		ret.AppendBits(other)
+	}
+	return ret
+}
+
+// SetRange sets all bits in the range [from, to) to the given value. This method will return an
+// error, leaving the bitlist unchanged, if the range does not lie within the bitlist.
+func (b *Bitlist64) SetRange(from, to uint64, val bool) error {
+	if err := checkRange(from, to, b.size); err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+
+	for idx := from >> wordSizeLog2; idx <= (to-1)>>wordSizeLog2; idx++ {
+		if val {
+			b.data[idx] |= rangeWordMask(idx, from, to)
+		} else {
+			b.data[idx] &^= rangeWordMask(idx, from, to)
+		}
+	}
+	return nil
+}
+
+// ClearRange sets all bits in the range [from, to) to 0. This method will return an error, leaving
+// the bitlist unchanged, if the range does not lie within the bitlist.
+func (b *Bitlist64) ClearRange(from, to uint64) error {
+	return b.SetRange(from, to, false)
+}
+
+// FlipRange inverts all bits in the range [from, to). This method will return an error, leaving
+// the bitlist unchanged, if the range does not lie within the bitlist.
+func (b *Bitlist64) FlipRange(from, to uint64) error {
+	if err := checkRange(from, to, b.size); err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+
+	for idx := from >> wordSizeLog2; idx <= (to-1)>>wordSizeLog2; idx++ {
+		b.data[idx] ^= rangeWordMask(idx, from, to)
+	}
+	return nil
+}
+
+// CountRange returns the number of bits set to 1 in the range [from, to). This method will return
+// an error if the range does not lie within the bitlist.
+func (b *Bitlist64) CountRange(from, to uint64) (uint64, error) {
+	if err := checkRange(from, to, b.size); err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 0, nil
+	}
+
+	var cnt int
+	for idx := from >> wordSizeLog2; idx <= (to-1)>>wordSizeLog2; idx++ {
+		cnt += bits.OnesCount64(b.data[idx] & rangeWordMask(idx, from, to))
+	}
+	return uint64(cnt), nil
+}
+
+// littleEndianBytes returns all words of the bitlist as an untrimmed array of bytes.
+func (b *Bitlist64) littleEndianBytes() []byte {
+	ret := make([]byte, len(b.data)*bytesInWord)
+	for idx, word := range b.data {
+		start := idx << bytesInWordLog2
+		binary.LittleEndian.PutUint64(ret[start:start+bytesInWord], word)
+	}
+	return ret
+}
+
 // numWordsRequired calculates how many words are required to hold bitlist of n bits.
 func numWordsRequired(n uint64) int {
 	return int((n + (wordSize - 1)) >> wordSizeLog2)
 }
 
+// shiftUp moves all bits of the bitlist by s towards higher indices.
+func (b *Bitlist64) shiftUp(s uint64) {
+	if s >= b.size {
+		for idx := range b.data {
+			b.data[idx] = 0
+		}
+		return
+	}
+
+	wordShift, bitShift := s>>wordSizeLog2, s%wordSize
+	for idx := len(b.data) - 1; idx >= 0; idx-- {
+		var word uint64
+		if src := uint64(idx); src >= wordShift {
+			src -= wordShift
+			word = b.data[src] << bitShift
+			if bitShift > 0 && src > 0 {
+				word |= b.data[src-1] >> (wordSize - bitShift)
+			}
+		}
+		b.data[idx] = word
+	}
+	b.clearUnusedBits()
+}
+
+// shiftDown moves all bits of the bitlist by s towards lower indices.
+func (b *Bitlist64) shiftDown(s uint64) {
+	if s >= b.size {
+		for idx := range b.data {
+			b.data[idx] = 0
+		}
+		return
+	}
+
+	// Make sure no unused bits are shifted into the bitlist.
+	b.clearUnusedBits()
+	wordShift, bitShift := s>>wordSizeLog2, s%wordSize
+	for idx := range b.data {
+		var word uint64
+		if src := uint64(idx) + wordShift; src < uint64(len(b.data)) {
+			word = b.data[src] >> bitShift
+			if bitShift > 0 && src+1 < uint64(len(b.data)) {
+				word |= b.data[src+1] << (wordSize - bitShift)
+			}
+		}
+		b.data[idx] = word
+	}
+}
+
+// rotate moves all bits of the bitlist by s towards higher indices, wrapping around at the end.
+func (b *Bitlist64) rotate(s uint64) {
+	if b.size == 0 || s%b.size == 0 {
+		return
+	}
+	s %= b.size
+
+	wrapped := b.Clone()
+	b.shiftUp(s)
+	wrapped.shiftDown(b.size - s)
+	for idx, word := range wrapped.data {
+		b.data[idx] |= word
+	}
+}
+
 // clearUnusedBits zeroes unused bits in the last word.
 func (b *Bitlist64) clearUnusedBits() {
 	// Unless bitlist is divisible by a word evenly, we need to zero unused bits in the last word.
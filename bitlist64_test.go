@@ -2,8 +2,11 @@ package bitfield
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -305,6 +308,63 @@ func TestBitlist64_NewBitlist64FromBytes(t *testing.T) {
 	}
 }
 
+func TestBitlist64FromSSZ(t *testing.T) {
+	tests := []struct {
+		data    []byte
+		limit   uint64
+		want    *Bitlist64
+		wantErr error
+	}{
+		{
+			data:    []byte{},
+			limit:   8,
+			wantErr: ErrBitlistMissingDelimiter,
+		},
+		{
+			data:    []byte{0x0b, 0x00},
+			limit:   8,
+			wantErr: ErrBitlistTrailingBytes,
+		},
+		{
+			data:    []byte{0xff, 0x01},
+			limit:   7,
+			wantErr: ErrBitlistExceedsLimit,
+		},
+		{
+			data:  []byte{0x01},
+			limit: 0,
+			want:  NewBitlist64(0),
+		},
+		{
+			data:  []byte{0x0b},
+			limit: 8,
+			want: &Bitlist64{
+				size: 3,
+				data: []uint64{0x03},
+			},
+		},
+		{
+			data:  []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x03},
+			limit: 2048,
+			want: &Bitlist64{
+				size: 65,
+				data: []uint64{0xffffffffffffffff, 0x01},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Bitlist64FromSSZ(tt.data, tt.limit)
+		if err != tt.wantErr {
+			t.Errorf("Bitlist64FromSSZ(%#x, %d) error = %v, wanted %v", tt.data, tt.limit, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Bitlist64FromSSZ(%#x, %d) = %+v, wanted %+v", tt.data, tt.limit, got, tt.want)
+		}
+	}
+}
+
 func TestBitlist64_ToBitlist(t *testing.T) {
 	tests := []struct {
 		size            uint64
@@ -1357,6 +1417,109 @@ func TestBitlist64_Xor(t *testing.T) {
 	})
 }
 
+func TestBitlist64_AndNot(t *testing.T) {
+	tests := []struct {
+		a    *Bitlist64
+		b    *Bitlist64
+		want *Bitlist64
+	}{
+		{
+			a:    NewBitlist64From([]uint64{0x02}), // 0b00000010
+			b:    NewBitlist64From([]uint64{0x03}), // 0b00000011
+			want: NewBitlist64From([]uint64{0x00}), // 0b00000000
+		},
+		{
+			a:    NewBitlist64From([]uint64{0x13}), // 0b00010011
+			b:    NewBitlist64From([]uint64{0x15}), // 0b00010101
+			want: NewBitlist64From([]uint64{0x02}), // 0b00000010
+		},
+		{
+			a:    NewBitlist64From([]uint64{0x1F, 0x03}), // 0b00011111, 0b00000011
+			b:    NewBitlist64From([]uint64{0x13, 0x02}), // 0b00010011, 0b00000010
+			want: NewBitlist64From([]uint64{0x0c, 0x01}), // 0b00001100, 0b00000001
+		},
+		{
+			a:    NewBitlist64From([]uint64{0xFF, 0x87}), // 0b11111111, 0x10000111
+			b:    NewBitlist64From([]uint64{0x13, 0x8F}), // 0b00010011, 0x10001111
+			want: NewBitlist64From([]uint64{0xec, 0x00}), // 0b11101100, 0x00000000
+		},
+		{
+			a:    NewBitlist64From([]uint64{allBitsSet, 0x8000000000000001}),
+			b:    NewBitlist64From([]uint64{0x00, 0x8000000000000000}),
+			want: NewBitlist64From([]uint64{allBitsSet, 0x01}),
+		},
+	}
+
+	t.Run("AndNot()", func(t *testing.T) {
+		for _, tt := range tests {
+			got, err := tt.a.AndNot(tt.b)
+			if err != nil {
+				t.Error(err)
+			}
+			if !reflect.DeepEqual(got.data, tt.want.data) {
+				t.Errorf("(%+v).AndNot(%+v) = %+v, wanted %x", tt.a, tt.b, got, tt.want)
+			}
+		}
+	})
+	t.Run("NoAllocAndNot()", func(t *testing.T) {
+		for _, tt := range tests {
+			res := tt.a.Clone()
+			// Make sure that no existing bits set interfere with operation. This is done to simulate
+			// the case when res variable is already populated from the previous run.
+			for i := uint64(0); i < res.Len(); i += 10 {
+				res.SetBitAt(i, true)
+			}
+			tt.a.NoAllocAndNot(tt.b, res)
+			if !reflect.DeepEqual(res.data, tt.want.data) {
+				t.Errorf("(%+v).NoAllocAndNot(%+v) = %+v, wanted %x", tt.a, tt.b, res.data, tt.want)
+			}
+		}
+	})
+	t.Run("AndNotCount()", func(t *testing.T) {
+		for _, tt := range tests {
+			if got, err := tt.a.AndNotCount(tt.b); got != tt.want.Count() || err != nil {
+				t.Errorf("(%+v).AndNotCount(%+v) = %d, %v, wanted %d", tt.a, tt.b, got, err, tt.want.Count())
+			}
+		}
+	})
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size are not part of the difference.
+		a := &Bitlist64{size: 3, data: []uint64{0xfb}}
+		b := &Bitlist64{size: 3, data: []uint64{0x02}}
+		want := &Bitlist64{size: 3, data: []uint64{0x01}}
+		if got, err := a.AndNot(b); err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("(%+v).AndNot(%+v) = %+v, %v, wanted %+v", a, b, got, err, want)
+		}
+		if got, err := a.AndNotCount(b); got != 1 || err != nil {
+			t.Errorf("(%+v).AndNotCount(%+v) = %d, %v, wanted 1", a, b, got, err)
+		}
+	})
+	t.Run("check errors", func(t *testing.T) {
+		t.Run("AndNot()", func(t *testing.T) {
+			a := NewBitlist64(64)
+			b := NewBitlist64(128)
+			if _, err := a.AndNot(b); err != ErrBitlistDifferentLength {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+			}
+		})
+		t.Run("NoAllocAndNot() wrong length of result param", func(t *testing.T) {
+			a := NewBitlist64(64)
+			b := NewBitlist64(64)
+			ret := NewBitlist64(128)
+			if err := a.NoAllocAndNot(b, ret); err != ErrBitlistDifferentLength {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+			}
+		})
+		t.Run("AndNotCount()", func(t *testing.T) {
+			a := NewBitlist64(64)
+			b := NewBitlist64(128)
+			if _, err := a.AndNotCount(b); err != ErrBitlistDifferentLength {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+			}
+		})
+	})
+}
+
 func TestBitlist64_Not(t *testing.T) {
 	tests := []struct {
 		a    *Bitlist64
@@ -1453,6 +1616,839 @@ func TestBitlist64_Not(t *testing.T) {
 	})
 }
 
+func TestBitlist64_InPlace(t *testing.T) {
+	tests := []struct {
+		a *Bitlist64
+		b *Bitlist64
+	}{
+		{a: NewBitlist64(0), b: NewBitlist64(0)},
+		{a: NewBitlist64From([]uint64{0x13}), b: NewBitlist64From([]uint64{0x15})},
+		{a: NewBitlist64From([]uint64{0xFF, 0x87}), b: NewBitlist64From([]uint64{0x13, 0x8F})},
+		{a: &Bitlist64{size: 70, data: []uint64{allBitsSet, 0x21}}, b: &Bitlist64{size: 70, data: []uint64{0x0f, 0x3f}}},
+	}
+
+	for _, tt := range tests {
+		for _, op := range []struct {
+			name    string
+			inPlace func(a, b *Bitlist64) error
+			alloc   func(a, b *Bitlist64) (*Bitlist64, error)
+		}{
+			{"Or", (*Bitlist64).OrInPlace, (*Bitlist64).Or},
+			{"And", (*Bitlist64).AndInPlace, (*Bitlist64).And},
+			{"Xor", (*Bitlist64).XorInPlace, (*Bitlist64).Xor},
+			{"AndNot", (*Bitlist64).AndNotInPlace, (*Bitlist64).AndNot},
+		} {
+			want, err := op.alloc(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := tt.a.Clone()
+			if err := op.inPlace(got, tt.b); err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("(%+v).%sInPlace(%+v) = %+v, %v, wanted %+v", tt.a, op.name, tt.b, got, err, want)
+			}
+
+			// The receiver and the argument may be the same bitlist.
+			want, err = op.alloc(tt.a, tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = tt.a.Clone()
+			if err := op.inPlace(got, got); err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("(%+v).%sInPlace(itself) = %+v, %v, wanted %+v", tt.a, op.name, got, err, want)
+			}
+		}
+
+		got := tt.a.Clone()
+		got.NotInPlace()
+		if want := tt.a.Not(); !reflect.DeepEqual(got, want) {
+			t.Errorf("(%+v).NotInPlace() = %+v, wanted %+v", tt.a, got, want)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := NewBitlist64(64), NewBitlist64(128)
+		for _, fn := range []func(*Bitlist64) error{a.OrInPlace, a.AndInPlace, a.XorInPlace, a.AndNotInPlace} {
+			if err := fn(b); err != ErrBitlistDifferentLength {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+			}
+		}
+	})
+}
+
+func TestBitlist64_Shift(t *testing.T) {
+	tests := []struct {
+		bitlist *Bitlist64
+		shift   int
+		want    *Bitlist64
+	}{
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   1,
+			want:    &Bitlist64{size: 64, data: []uint64{0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -1,
+			want:    &Bitlist64{size: 64, data: []uint64{0x4000000000000000}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   63,
+			want:    &Bitlist64{size: 64, data: []uint64{0x8000000000000000}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -63,
+			want:    &Bitlist64{size: 64, data: []uint64{0x1}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   64,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -64,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   65,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -65,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   129,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -129,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   500,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -500,
+			want:    &Bitlist64{size: 64, data: []uint64{0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   1,
+			want:    &Bitlist64{size: 70, data: []uint64{0x555555555555554a, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -1,
+			want:    &Bitlist64{size: 70, data: []uint64{0x5555555555555552, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   63,
+			want:    &Bitlist64{size: 70, data: []uint64{0x8000000000000000, 0x12}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -63,
+			want:    &Bitlist64{size: 70, data: []uint64{0x55, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   64,
+			want:    &Bitlist64{size: 70, data: []uint64{0x0, 0x25}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -64,
+			want:    &Bitlist64{size: 70, data: []uint64{0x2a, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   65,
+			want:    &Bitlist64{size: 70, data: []uint64{0x0, 0xa}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -65,
+			want:    &Bitlist64{size: 70, data: []uint64{0x15, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   129,
+			want:    &Bitlist64{size: 70, data: []uint64{0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -129,
+			want:    &Bitlist64{size: 70, data: []uint64{0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   500,
+			want:    &Bitlist64{size: 70, data: []uint64{0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -500,
+			want:    &Bitlist64{size: 70, data: []uint64{0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   1,
+			want:    &Bitlist64{size: 130, data: []uint64{0x2, 0x1e1e, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -1,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000000, 0x8000000000000787, 0x1}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   63,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000000, 0x8000000000000000, 0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -63,
+			want:    &Bitlist64{size: 130, data: []uint64{0x1e1e, 0x6, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   64,
+			want:    &Bitlist64{size: 130, data: []uint64{0x0, 0x1, 0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -64,
+			want:    &Bitlist64{size: 130, data: []uint64{0xf0f, 0x3, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   65,
+			want:    &Bitlist64{size: 130, data: []uint64{0x0, 0x2, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -65,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000787, 0x1, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   129,
+			want:    &Bitlist64{size: 130, data: []uint64{0x0, 0x0, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -129,
+			want:    &Bitlist64{size: 130, data: []uint64{0x1, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   500,
+			want:    &Bitlist64{size: 130, data: []uint64{0x0, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -500,
+			want:    &Bitlist64{size: 130, data: []uint64{0x0, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   1,
+			want:    &Bitlist64{size: 200, data: []uint64{0x1fe, 0x2000000000, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -1,
+			want:    &Bitlist64{size: 200, data: []uint64{0x7f, 0x800000000, 0x0, 0x40}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   63,
+			want:    &Bitlist64{size: 200, data: []uint64{0x8000000000000000, 0x7f, 0x800000000, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -63,
+			want:    &Bitlist64{size: 200, data: []uint64{0x2000000000, 0x0, 0x100, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   64,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0xff, 0x1000000000, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -64,
+			want:    &Bitlist64{size: 200, data: []uint64{0x1000000000, 0x0, 0x80, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   65,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0x1fe, 0x2000000000, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -65,
+			want:    &Bitlist64{size: 200, data: []uint64{0x800000000, 0x0, 0x40, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   129,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0x0, 0x1fe, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -129,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0x40, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   500,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0x0, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -500,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0x0, 0x0, 0x0}},
+		},
+	}
+
+	for _, tt := range tests {
+		original := tt.bitlist.Clone()
+		tt.bitlist.Shift(tt.shift)
+		if !reflect.DeepEqual(tt.bitlist, tt.want) {
+			t.Errorf("(%+v).Shift(%d) = %+v, wanted %+v", original, tt.shift, tt.bitlist, tt.want)
+		}
+
+		// Byte backed bitlist must produce the same result.
+		b := original.ToBitlist()
+		b.Shift(tt.shift)
+		if !bytes.Equal(b, tt.want.ToBitlist()) {
+			t.Errorf("(%#x).Shift(%d) = %#x, wanted %#x", original.ToBitlist(), tt.shift, b, tt.want.ToBitlist())
+		}
+	}
+}
+
+func TestBitlist64_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitlist *Bitlist64
+		shift   int
+		want    *Bitlist64
+	}{
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   1,
+			want:    &Bitlist64{size: 64, data: []uint64{0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -1,
+			want:    &Bitlist64{size: 64, data: []uint64{0xc000000000000000}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   63,
+			want:    &Bitlist64{size: 64, data: []uint64{0xc000000000000000}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   64,
+			want:    &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   65,
+			want:    &Bitlist64{size: 64, data: []uint64{0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -129,
+			want:    &Bitlist64{size: 64, data: []uint64{0xc000000000000000}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   1,
+			want:    &Bitlist64{size: 70, data: []uint64{0x555555555555554b, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -1,
+			want:    &Bitlist64{size: 70, data: []uint64{0x5555555555555552, 0x35}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   63,
+			want:    &Bitlist64{size: 70, data: []uint64{0xd555555555555555, 0x12}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   64,
+			want:    &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaaa, 0x25}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   65,
+			want:    &Bitlist64{size: 70, data: []uint64{0x5555555555555555, 0xb}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -129,
+			want:    &Bitlist64{size: 70, data: []uint64{0x5555555555552d55, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   1,
+			want:    &Bitlist64{size: 130, data: []uint64{0x3, 0x1e1e, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -1,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000000, 0x8000000000000787, 0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   63,
+			want:    &Bitlist64{size: 130, data: []uint64{0xe0000000000001e1, 0x8000000000000000, 0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   64,
+			want:    &Bitlist64{size: 130, data: []uint64{0xc0000000000003c3, 0x1, 0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   65,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000787, 0x3, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -129,
+			want:    &Bitlist64{size: 130, data: []uint64{0x3, 0x1e1e, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   1,
+			want:    &Bitlist64{size: 200, data: []uint64{0x1ff, 0x2000000000, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -1,
+			want:    &Bitlist64{size: 200, data: []uint64{0x7f, 0x800000000, 0x0, 0xc0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   63,
+			want:    &Bitlist64{size: 200, data: []uint64{0xc000000000000000, 0x7f, 0x800000000, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   64,
+			want:    &Bitlist64{size: 200, data: []uint64{0x8000000000000000, 0xff, 0x1000000000, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   65,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0x1ff, 0x2000000000, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -129,
+			want:    &Bitlist64{size: 200, data: []uint64{0x0, 0x7fc0, 0x80000000000, 0x0}},
+		},
+	}
+
+	for _, tt := range tests {
+		original := tt.bitlist.Clone()
+		tt.bitlist.RotateLeft(tt.shift)
+		if !reflect.DeepEqual(tt.bitlist, tt.want) {
+			t.Errorf("(%+v).RotateLeft(%d) = %+v, wanted %+v", original, tt.shift, tt.bitlist, tt.want)
+		}
+
+		// Byte backed bitlist must produce the same result.
+		b := original.ToBitlist()
+		b.RotateLeft(tt.shift)
+		if !bytes.Equal(b, tt.want.ToBitlist()) {
+			t.Errorf("(%#x).RotateLeft(%d) = %#x, wanted %#x", original.ToBitlist(), tt.shift, b, tt.want.ToBitlist())
+		}
+	}
+}
+
+func TestBitlist64_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitlist *Bitlist64
+		shift   int
+		want    *Bitlist64
+	}{
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   1,
+			want:    &Bitlist64{size: 64, data: []uint64{0xc000000000000000}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -1,
+			want:    &Bitlist64{size: 64, data: []uint64{0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   63,
+			want:    &Bitlist64{size: 64, data: []uint64{0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   64,
+			want:    &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   65,
+			want:    &Bitlist64{size: 64, data: []uint64{0xc000000000000000}},
+		},
+		{
+			bitlist: &Bitlist64{size: 64, data: []uint64{0x8000000000000001}},
+			shift:   -129,
+			want:    &Bitlist64{size: 64, data: []uint64{0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   1,
+			want:    &Bitlist64{size: 70, data: []uint64{0x5555555555555552, 0x35}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -1,
+			want:    &Bitlist64{size: 70, data: []uint64{0x555555555555554b, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   63,
+			want:    &Bitlist64{size: 70, data: []uint64{0x55555555555552d5, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   64,
+			want:    &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaa96a, 0x2a}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   65,
+			want:    &Bitlist64{size: 70, data: []uint64{0x55555555555554b5, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 70, data: []uint64{0xaaaaaaaaaaaaaaa5, 0x2a}},
+			shift:   -129,
+			want:    &Bitlist64{size: 70, data: []uint64{0x2d55555555555555, 0x15}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   1,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000000, 0x8000000000000787, 0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -1,
+			want:    &Bitlist64{size: 130, data: []uint64{0x3, 0x1e1e, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   63,
+			want:    &Bitlist64{size: 130, data: []uint64{0x1e1e, 0xe, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   64,
+			want:    &Bitlist64{size: 130, data: []uint64{0xf0f, 0x7, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   65,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000787, 0x3, 0x2}},
+		},
+		{
+			bitlist: &Bitlist64{size: 130, data: []uint64{0x1, 0xf0f, 0x3}},
+			shift:   -129,
+			want:    &Bitlist64{size: 130, data: []uint64{0x8000000000000000, 0x8000000000000787, 0x3}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   1,
+			want:    &Bitlist64{size: 200, data: []uint64{0x7f, 0x800000000, 0x0, 0xc0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -1,
+			want:    &Bitlist64{size: 200, data: []uint64{0x1ff, 0x2000000000, 0x0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   63,
+			want:    &Bitlist64{size: 200, data: []uint64{0x2000000000, 0x0, 0x1ff00, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   64,
+			want:    &Bitlist64{size: 200, data: []uint64{0x1000000000, 0x0, 0xff80, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   65,
+			want:    &Bitlist64{size: 200, data: []uint64{0x800000000, 0x0, 0x7fc0, 0x0}},
+		},
+		{
+			bitlist: &Bitlist64{size: 200, data: []uint64{0xff, 0x1000000000, 0x0, 0x80}},
+			shift:   -129,
+			want:    &Bitlist64{size: 200, data: []uint64{0x20000000, 0x0, 0x1ff, 0x0}},
+		},
+	}
+
+	for _, tt := range tests {
+		original := tt.bitlist.Clone()
+		tt.bitlist.RotateRight(tt.shift)
+		if !reflect.DeepEqual(tt.bitlist, tt.want) {
+			t.Errorf("(%+v).RotateRight(%d) = %+v, wanted %+v", original, tt.shift, tt.bitlist, tt.want)
+		}
+
+		// Byte backed bitlist must produce the same result.
+		b := original.ToBitlist()
+		b.RotateRight(tt.shift)
+		if !bytes.Equal(b, tt.want.ToBitlist()) {
+			t.Errorf("(%#x).RotateRight(%d) = %#x, wanted %#x", original.ToBitlist(), tt.shift, b, tt.want.ToBitlist())
+		}
+	}
+}
+
+func TestBitlist64_All(t *testing.T) {
+	tests := []struct {
+		bitlist   *Bitlist64
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitlist:   NewBitlist64(0),
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+		{
+			bitlist:   NewBitlist64(3),
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2},
+		},
+		{
+			bitlist:   &Bitlist64{size: 5, data: []uint64{0x15}},
+			want:      []uint64{0, 2, 4},
+			wantUnset: []uint64{1, 3},
+		},
+		{
+			bitlist:   &Bitlist64{size: 66, data: []uint64{0xfffffffffffffffe, 0x02}},
+			want:      append(seqUint64(1, 64), 65),
+			wantUnset: []uint64{0, 64},
+		},
+		{
+			bitlist:   &Bitlist64{size: 130, data: []uint64{0x8000000000000001, 0x00, 0x03}},
+			want:      []uint64{0, 63, 128, 129},
+			wantUnset: append(seqUint64(1, 63), seqUint64(64, 128)...),
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitlist.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%+v).All() = %v, wanted %v", tt.bitlist, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitlist.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%+v).AllUnset() = %v, wanted %v", tt.bitlist, got, tt.wantUnset)
+		}
+		// Iterator must match the slice based BitIndices.
+		var indices []uint64
+		for _, idx := range tt.bitlist.BitIndices() {
+			indices = append(indices, uint64(idx))
+		}
+		if got := slices.Collect(tt.bitlist.All()); !slices.Equal(got, indices) {
+			t.Errorf("(%+v).All() = %v, BitIndices() = %v", tt.bitlist, got, indices)
+		}
+	}
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size are not part of the bitlist.
+		b := &Bitlist64{size: 3, data: []uint64{0xfb}}
+		if got := slices.Collect(b.All()); !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("(%+v).All() = %v, wanted [0 1]", b, got)
+		}
+		if got := slices.Collect(b.AllUnset()); !slices.Equal(got, []uint64{2}) {
+			t.Errorf("(%+v).AllUnset() = %v, wanted [2]", b, got)
+		}
+	})
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range NewBitlist64From([]uint64{allBitsSet, allBitsSet}).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitlist64_NextPrev(t *testing.T) {
+	type result struct {
+		idx uint64
+		ok  bool
+	}
+	tests := []struct {
+		bitlist   *Bitlist64
+		from      uint64
+		nextSet   result
+		nextClear result
+		prevSet   result
+		prevClear result
+	}{
+		{
+			bitlist:   NewBitlist64(0),
+			from:      0,
+			nextSet:   result{0, false},
+			nextClear: result{0, false},
+			prevSet:   result{0, false},
+			prevClear: result{0, false},
+		},
+		{
+			bitlist:   &Bitlist64{size: 5, data: []uint64{0x15}},
+			from:      1,
+			nextSet:   result{2, true},
+			nextClear: result{1, true},
+			prevSet:   result{0, true},
+			prevClear: result{1, true},
+		},
+		{
+			// Unused bits past the size are not reported as clear.
+			bitlist:   &Bitlist64{size: 5, data: []uint64{0x1f}},
+			from:      0,
+			nextSet:   result{0, true},
+			nextClear: result{0, false},
+			prevSet:   result{0, true},
+			prevClear: result{0, false},
+		},
+		{
+			bitlist:   &Bitlist64{size: 200, data: []uint64{0x01, 0x00, 0x00, 0x80}},
+			from:      1,
+			nextSet:   result{199, true},
+			nextClear: result{1, true},
+			prevSet:   result{0, true},
+			prevClear: result{1, true},
+		},
+		{
+			// Search backwards from past the end starts from the last bit.
+			bitlist:   &Bitlist64{size: 130, data: []uint64{allBitsSet, 0x01, 0x03}},
+			from:      1000,
+			nextSet:   result{0, false},
+			nextClear: result{0, false},
+			prevSet:   result{129, true},
+			prevClear: result{127, true},
+		},
+		{
+			bitlist:   &Bitlist64{size: 130, data: []uint64{allBitsSet, 0x01, 0x03}},
+			from:      63,
+			nextSet:   result{63, true},
+			nextClear: result{65, true},
+			prevSet:   result{63, true},
+			prevClear: result{0, false},
+		},
+	}
+
+	for _, tt := range tests {
+		if idx, ok := tt.bitlist.NextSet(tt.from); idx != tt.nextSet.idx || ok != tt.nextSet.ok {
+			t.Errorf("(%+v).NextSet(%d) = %d, %t, wanted %v", tt.bitlist, tt.from, idx, ok, tt.nextSet)
+		}
+		if idx, ok := tt.bitlist.NextClear(tt.from); idx != tt.nextClear.idx || ok != tt.nextClear.ok {
+			t.Errorf("(%+v).NextClear(%d) = %d, %t, wanted %v", tt.bitlist, tt.from, idx, ok, tt.nextClear)
+		}
+		if idx, ok := tt.bitlist.PrevSet(tt.from); idx != tt.prevSet.idx || ok != tt.prevSet.ok {
+			t.Errorf("(%+v).PrevSet(%d) = %d, %t, wanted %v", tt.bitlist, tt.from, idx, ok, tt.prevSet)
+		}
+		if idx, ok := tt.bitlist.PrevClear(tt.from); idx != tt.prevClear.idx || ok != tt.prevClear.ok {
+			t.Errorf("(%+v).PrevClear(%d) = %d, %t, wanted %v", tt.bitlist, tt.from, idx, ok, tt.prevClear)
+		}
+		checkNextPrev(t, tt.bitlist)
+	}
+}
+
+// seqUint64 returns the sequence of integers in [from, to).
+func seqUint64(from, to uint64) []uint64 {
+	ret := make([]uint64, 0, to-from)
+	for i := from; i < to; i++ {
+		ret = append(ret, i)
+	}
+	return ret
+}
+
+func TestBitlist64_RankSelect(t *testing.T) {
+	tests := []struct {
+		bitlist *Bitlist64
+		ranks   []uint64 // Rank of every index in [0, Len()].
+		selects []uint64 // Select of every k in [0, Count()).
+	}{
+		{
+			bitlist: NewBitlist64(0),
+			ranks:   []uint64{0},
+			selects: []uint64{},
+		},
+		{
+			bitlist: NewBitlist64(3),
+			ranks:   []uint64{0, 0, 0, 0},
+			selects: []uint64{},
+		},
+		{
+			bitlist: &Bitlist64{size: 5, data: []uint64{0x15}},
+			ranks:   []uint64{0, 1, 1, 2, 2, 3},
+			selects: []uint64{0, 2, 4},
+		},
+		{
+			bitlist: &Bitlist64{size: 66, data: []uint64{0x8000000000000001, 0x02}},
+			ranks: append(append([]uint64{0}, slices.Repeat([]uint64{1}, 63)...),
+				2, 2, 3),
+			selects: []uint64{0, 63, 65},
+		},
+		{
+			// Bits set past the size are not counted.
+			bitlist: &Bitlist64{size: 3, data: []uint64{0xfb}},
+			ranks:   []uint64{0, 1, 2, 2},
+			selects: []uint64{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		for idx, want := range tt.ranks {
+			if got := tt.bitlist.Rank(uint64(idx)); got != want {
+				t.Errorf("(%+v).Rank(%d) = %d, wanted %d", tt.bitlist, idx, got, want)
+			}
+		}
+		for k, want := range tt.selects {
+			if got, ok := tt.bitlist.Select(uint64(k)); !ok || got != want {
+				t.Errorf("(%+v).Select(%d) = %d, %t, wanted %d, true", tt.bitlist, k, got, ok, want)
+			}
+		}
+		if got, ok := tt.bitlist.Select(uint64(len(tt.selects))); ok {
+			t.Errorf("(%+v).Select(%d) = %d, true, wanted false", tt.bitlist, len(tt.selects), got)
+		}
+		// Rank past the end is the total count.
+		if got := tt.bitlist.Rank(tt.bitlist.Len() + 100); got != uint64(len(tt.selects)) {
+			t.Errorf("(%+v).Rank(%d) = %d, wanted %d", tt.bitlist, tt.bitlist.Len()+100, got, len(tt.selects))
+		}
+	}
+}
+
 func TestBitlist64_BitIndices(t *testing.T) {
 	tests := []struct {
 		a    *Bitlist64
@@ -1500,3 +2496,148 @@ func TestBitlist64_BitIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestBitlist64_Resize(t *testing.T) {
+	tests := []struct {
+		a    *Bitlist64
+		n    uint64
+		want *Bitlist64
+	}{
+		{
+			a:    NewBitlist64(0),
+			n:    3,
+			want: NewBitlist64(3),
+		},
+		{
+			a:    NewBitlist64From([]uint64{0xff}),
+			n:    4,
+			want: &Bitlist64{size: 4, data: []uint64{0x0f}},
+		},
+		{
+			a:    NewBitlist64From([]uint64{0xff, 0x01}),
+			n:    64,
+			want: NewBitlist64From([]uint64{0xff}),
+		},
+		{
+			a:    NewBitlist64From([]uint64{0xff, 0x01}),
+			n:    0,
+			want: &Bitlist64{size: 0, data: []uint64{}},
+		},
+		{
+			a:    &Bitlist64{size: 4, data: []uint64{0x0f}},
+			n:    130,
+			want: &Bitlist64{size: 130, data: []uint64{0x0f, 0x00, 0x00}},
+		},
+	}
+
+	for _, tt := range tests {
+		a := tt.a.Clone()
+		a.Resize(tt.n)
+		if !reflect.DeepEqual(a, tt.want) {
+			t.Errorf("(%+v).Resize(%d) = %+v, wanted %+v", tt.a, tt.n, a, tt.want)
+		}
+	}
+
+	t.Run("regrow", func(t *testing.T) {
+		// Bits dropped by shrinking must not reappear when the bitlist grows again.
+		b := NewBitlist64From([]uint64{allBitsSet, allBitsSet})
+		b.Resize(10)
+		b.Resize(128)
+		want := NewBitlist64From([]uint64{0x3ff, 0x00})
+		if !reflect.DeepEqual(b, want) {
+			t.Errorf("Resize() = %+v, wanted %+v", b, want)
+		}
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		b := NewBitlist64From([]uint64{0xff})
+		b.Truncate(100)
+		if b.Len() != 64 {
+			t.Errorf("Truncate(100) changed length to %d", b.Len())
+		}
+		b.Truncate(2)
+		if !reflect.DeepEqual(b, &Bitlist64{size: 2, data: []uint64{0x03}}) {
+			t.Errorf("Truncate(2) = %+v", b)
+		}
+	})
+
+	t.Run("append", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		want := NewBitlist(0)
+		b := NewBitlist64(0)
+		for i := 0; i < 100; i++ {
+			if r.Intn(2) == 0 {
+				val := r.Intn(2) == 0
+				b.Append(val)
+				want = want.Append(val)
+			} else {
+				other := NewBitlist64(uint64(r.Intn(150)))
+				for j := uint64(0); j < other.Len(); j++ {
+					other.SetBitAt(j, r.Intn(2) == 0)
+				}
+				b.AppendBits(other)
+				want = want.AppendBits(other.ToBitlist())
+			}
+		}
+		b.AppendBits(b)
+		want = want.AppendBits(want)
+
+		if got := b.ToBitlist(); !bytes.Equal(got, want) {
+			t.Errorf("ToBitlist() = %x, wanted %x", got, want)
+		}
+		if len(b.data) != numWordsRequired(b.Len()) {
+			t.Errorf("len(data) = %d, wanted %d", len(b.data), numWordsRequired(b.Len()))
+		}
+	})
+}
+
+func TestBitlist64_SliceConcat(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b := NewBitlist64(300)
+	for i := uint64(0); i < b.Len(); i++ {
+		b.SetBitAt(i, r.Intn(2) == 0)
+	}
+
+	for from := uint64(0); from <= b.Len(); from += 13 {
+		for to := from; to <= b.Len(); to += 11 {
+			got, err := b.Slice(from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := b.ToBitlist().Slice(from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.ToBitlist(), want) {
+				t.Errorf("Slice(%d, %d) = %x, wanted %x", from, to, got.ToBitlist(), want)
+			}
+
+			// Splitting the bitlist and joining the parts back must give the original.
+			head, _ := b.Slice(0, from)
+			tail, _ := b.Slice(to, b.Len())
+			if joined := head.Concat(got, tail); !reflect.DeepEqual(joined, b) {
+				t.Errorf("Concat() of the parts split at %d, %d = %+v, wanted %+v", from, to, joined, b)
+			}
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, rng := range [][2]uint64{{0, 301}, {50, 49}, {301, 301}} {
+			if _, err := b.Slice(rng[0], rng[1]); !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("Slice(%d, %d): wrong error returned. Wanted %v, got %v", rng[0], rng[1], ErrInvalidRange, err)
+			}
+		}
+	})
+}
+
+func TestBitlist64_Range(t *testing.T) {
+	for _, n := range []uint64{0, 1, 63, 64, 65, 200} {
+		checkRangeOps(t, NewBitlist64(n))
+	}
+}
+
+func TestBitlist64_TryBitAt(t *testing.T) {
+	for _, n := range []uint64{0, 1, 64, 100} {
+		checkStrictAccessors(t, NewBitlist64(n))
+	}
+}
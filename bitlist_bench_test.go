@@ -318,6 +318,23 @@ func BenchmarkBitlist_Or(b *testing.B) {
 					s.NoAllocOr(s2, result)
 				}
 			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocOr(s1, result)
+					s.NoAllocOr(s2, result)
+				}
+			})
 		})
 	}
 }
@@ -380,6 +397,25 @@ func BenchmarkBitlist_OrCount(b *testing.B) {
 					result.Count()
 				}
 			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocOr(s1, result)
+					result.Count()
+					s.NoAllocOr(s2, result)
+					result.Count()
+				}
+			})
 			b.Run("[]uint64 (OrCount)", func(b *testing.B) {
 				b.StopTimer()
 				s := NewBitlist64(n)
@@ -396,6 +432,22 @@ func BenchmarkBitlist_OrCount(b *testing.B) {
 					s.OrCount(s2)
 				}
 			})
+			b.Run("[]byte (OrCount)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.OrCount(s1)
+					s.OrCount(s2)
+				}
+			})
 		})
 	}
 }
@@ -452,6 +504,23 @@ func BenchmarkBitlist_And(b *testing.B) {
 					s.NoAllocAnd(s2, result)
 				}
 			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocAnd(s1, result)
+					s.NoAllocAnd(s2, result)
+				}
+			})
 		})
 	}
 }
@@ -514,6 +583,25 @@ func BenchmarkBitlist_AndCount(b *testing.B) {
 					result.Count()
 				}
 			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocAnd(s1, result)
+					result.Count()
+					s.NoAllocAnd(s2, result)
+					result.Count()
+				}
+			})
 			b.Run("[]uint64 (AndCount)", func(b *testing.B) {
 				b.StopTimer()
 				s := NewBitlist64(n)
@@ -530,6 +618,22 @@ func BenchmarkBitlist_AndCount(b *testing.B) {
 					s.AndCount(s2)
 				}
 			})
+			b.Run("[]byte (AndCount)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.AndCount(s1)
+					s.AndCount(s2)
+				}
+			})
 		})
 	}
 }
@@ -586,6 +690,23 @@ func BenchmarkBitlist_Xor(b *testing.B) {
 					s.NoAllocXor(s2, result)
 				}
 			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocXor(s1, result)
+					s.NoAllocXor(s2, result)
+				}
+			})
 		})
 	}
 }
@@ -648,6 +769,25 @@ func BenchmarkBitlist_XorCount(b *testing.B) {
 					result.Count()
 				}
 			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocXor(s1, result)
+					result.Count()
+					s.NoAllocXor(s2, result)
+					result.Count()
+				}
+			})
 			b.Run("[]uint64 (XorCount)", func(b *testing.B) {
 				b.StopTimer()
 				s := NewBitlist64(n)
@@ -664,6 +804,305 @@ func BenchmarkBitlist_XorCount(b *testing.B) {
 					s.XorCount(s2)
 				}
 			})
+			b.Run("[]byte (XorCount)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.XorCount(s1)
+					s.XorCount(s2)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkBitlist_AndNot(b *testing.B) {
+	for n := uint64(0); n <= 2048; n += 256 {
+		b.Run(fmt.Sprintf("size:%d", n), func(b *testing.B) {
+			b.Run("[]byte", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.AndNot(s1)
+					s.AndNot(s2)
+				}
+			})
+			b.Run("[]uint64", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist64(n)
+				s1 := NewBitlist64(n) // has overlaps
+				s2 := NewBitlist64(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.AndNot(s1)
+					s.AndNot(s2)
+				}
+			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocAndNot(s1, result)
+					s.NoAllocAndNot(s2, result)
+				}
+			})
+			b.Run("[]uint64 (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist64(n)
+				s1 := NewBitlist64(n) // has overlaps
+				s2 := NewBitlist64(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocAndNot(s1, result)
+					s.NoAllocAndNot(s2, result)
+				}
+			})
+			b.Run("[]byte (AndNotCount)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				s1 := NewBitlist(n) // has overlaps
+				s2 := NewBitlist(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.AndNotCount(s1)
+					s.AndNotCount(s2)
+				}
+			})
+			b.Run("[]uint64 (AndNotCount)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist64(n)
+				s1 := NewBitlist64(n) // has overlaps
+				s2 := NewBitlist64(n) // no overlaps
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+					s1.SetBitAt(i, true)
+					s2.SetBitAt(i+1, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.AndNotCount(s1)
+					s.AndNotCount(s2)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkBitlist_UnionAll(b *testing.B) {
+	const numSrcs = 128
+	for n := uint64(256); n <= 2048; n += 256 {
+		b.Run(fmt.Sprintf("size:%d", n), func(b *testing.B) {
+			b.Run("[]byte (pairwise)", func(b *testing.B) {
+				b.StopTimer()
+				srcs := make([]Bitlist, numSrcs)
+				for i := range srcs {
+					srcs[i] = NewBitlist(n)
+					srcs[i].SetBitAt(uint64(i)%n, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					acc := srcs[0]
+					for _, src := range srcs[1:] {
+						acc, _ = acc.Or(src)
+					}
+				}
+			})
+			b.Run("[]byte (UnionAll)", func(b *testing.B) {
+				b.StopTimer()
+				srcs := make([]Bitlist, numSrcs)
+				for i := range srcs {
+					srcs[i] = NewBitlist(n)
+					srcs[i].SetBitAt(uint64(i)%n, true)
+				}
+				dst := NewBitlist(n)
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					BitlistUnionAll(dst, srcs...)
+				}
+			})
+			b.Run("[]uint64 (pairwise)", func(b *testing.B) {
+				b.StopTimer()
+				srcs := make([]*Bitlist64, numSrcs)
+				for i := range srcs {
+					srcs[i] = NewBitlist64(n)
+					srcs[i].SetBitAt(uint64(i)%n, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					acc := srcs[0]
+					for _, src := range srcs[1:] {
+						acc, _ = acc.Or(src)
+					}
+				}
+			})
+			b.Run("[]uint64 (UnionAll)", func(b *testing.B) {
+				b.StopTimer()
+				srcs := make([]*Bitlist64, numSrcs)
+				for i := range srcs {
+					srcs[i] = NewBitlist64(n)
+					srcs[i].SetBitAt(uint64(i)%n, true)
+				}
+				dst := NewBitlist64(n)
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					UnionAll(dst, srcs...)
+				}
+			})
+			b.Run("[]uint64 (UnionCount)", func(b *testing.B) {
+				b.StopTimer()
+				srcs := make([]*Bitlist64, numSrcs)
+				for i := range srcs {
+					srcs[i] = NewBitlist64(n)
+					srcs[i].SetBitAt(uint64(i)%n, true)
+				}
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					UnionCount(srcs...)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkBitlist_MaxCover(b *testing.B) {
+	const numCandidates = 128
+	const k = 16
+	for n := uint64(256); n <= 2048; n += 256 {
+		b.Run(fmt.Sprintf("size:%d", n), func(b *testing.B) {
+			candidates := make([]*Bitlist64, numCandidates)
+			for i := range candidates {
+				candidates[i] = NewBitlist64(n)
+				for j := uint64(i); j < n; j += uint64(i%7 + 3) {
+					candidates[i].SetBitAt(j, true)
+				}
+			}
+			b.Run("[]uint64", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					MaxCover(candidates, k, nil)
+				}
+			})
+			b.Run("[]uint64 (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				union := NewBitlist64(n)
+				selected := make([]int, 0, k)
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					NoAllocMaxCover(candidates, k, nil, union, selected)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkBitlist_EWAH(b *testing.B) {
+	const n = uint64(1 << 20)
+	for _, tt := range []struct {
+		name string
+		val  bool
+	}{
+		{name: "mostly clear", val: true},
+		{name: "mostly set", val: false},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			// A participation snapshot where one in every 1000 validators differs from the rest.
+			s := NewBitlist64(n)
+			if err := s.SetRange(0, n, !tt.val); err != nil {
+				b.Fatal(err)
+			}
+			for i := uint64(0); i < n; i += 1000 {
+				s.SetBitAt(i, tt.val)
+			}
+			encoded := EncodeEWAH(s)
+			raw := s.littleEndianBytes()
+
+			b.Run("[]uint64 Bytes", func(b *testing.B) {
+				b.ReportMetric(float64(len(s.Bytes())), "size")
+				for i := 0; i < b.N; i++ {
+					s.Bytes()
+				}
+			})
+			b.Run("[]uint64 EncodeEWAH", func(b *testing.B) {
+				b.ReportMetric(float64(len(encoded)), "size")
+				for i := 0; i < b.N; i++ {
+					EncodeEWAH(s)
+				}
+			})
+			b.Run("[]uint64 NewBitlist64FromBytes", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := NewBitlist64FromBytes(n, raw); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run("[]uint64 DecodeEWAH", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := DecodeEWAH(encoded, n); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run("[]uint64 Or", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := s.Or(s); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run("[]uint64 OrEWAH", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := OrEWAH(encoded, encoded); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run("[]uint64 AndEWAH", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := AndEWAH(encoded, encoded); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
 		})
 	}
 }
@@ -705,6 +1144,18 @@ func BenchmarkBitlist_Not(b *testing.B) {
 					s.NoAllocNot(result)
 				}
 			})
+			b.Run("[]byte (noalloc)", func(b *testing.B) {
+				b.StopTimer()
+				s := NewBitlist(n)
+				for i := uint64(0); i < n; i += 100 {
+					s.SetBitAt(i, true)
+				}
+				result := s.Clone()
+				b.StartTimer()
+				for i := 0; i < b.N; i++ {
+					s.NoAllocNot(result)
+				}
+			})
 		})
 	}
 }
@@ -747,6 +1198,42 @@ func BenchmarkBitlist_BitIndices(b *testing.B) {
 						s.NoAllocBitIndices(indices)
 					}
 				})
+				b.Run("[]byte (noalloc)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist(n)
+					for i := uint64(0); i < n; i += 10 {
+						s.SetBitAt(i, true)
+					}
+					indices := make([]int, s.Count())
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						s.NoAllocBitIndices(indices)
+					}
+				})
+				b.Run("[]uint64 (iter)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist64(n)
+					for i := uint64(0); i < n; i += 10 {
+						s.SetBitAt(i, true)
+					}
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						for range s.All() {
+						}
+					}
+				})
+				b.Run("[]byte (iter)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist(n)
+					for i := uint64(0); i < n; i += 10 {
+						s.SetBitAt(i, true)
+					}
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						for range s.All() {
+						}
+					}
+				})
 			})
 		})
 		b.Run("up to half bitlist non empty", func(b *testing.B) {
@@ -785,6 +1272,42 @@ func BenchmarkBitlist_BitIndices(b *testing.B) {
 						s.NoAllocBitIndices(indices)
 					}
 				})
+				b.Run("[]byte (noalloc)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist(n)
+					for i := uint64(0); i < n/2; i += 10 {
+						s.SetBitAt(i, true)
+					}
+					indices := make([]int, s.Count())
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						s.NoAllocBitIndices(indices)
+					}
+				})
+				b.Run("[]uint64 (iter)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist64(n)
+					for i := uint64(0); i < n/2; i += 10 {
+						s.SetBitAt(i, true)
+					}
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						for range s.All() {
+						}
+					}
+				})
+				b.Run("[]byte (iter)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist(n)
+					for i := uint64(0); i < n/2; i += 10 {
+						s.SetBitAt(i, true)
+					}
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						for range s.All() {
+						}
+					}
+				})
 			})
 		})
 		b.Run("only single bit set", func(b *testing.B) {
@@ -817,6 +1340,36 @@ func BenchmarkBitlist_BitIndices(b *testing.B) {
 						s.NoAllocBitIndices(indices)
 					}
 				})
+				b.Run("[]byte (noalloc)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist(n)
+					s.SetBitAt(n, true)
+					indices := make([]int, s.Count())
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						s.NoAllocBitIndices(indices)
+					}
+				})
+				b.Run("[]uint64 (iter)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist64(n)
+					s.SetBitAt(n, true)
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						for range s.All() {
+						}
+					}
+				})
+				b.Run("[]byte (iter)", func(b *testing.B) {
+					b.StopTimer()
+					s := NewBitlist(n)
+					s.SetBitAt(n, true)
+					b.StartTimer()
+					for i := 0; i < b.N; i++ {
+						for range s.All() {
+						}
+					}
+				})
 			})
 		})
 	}
@@ -2,8 +2,11 @@ package bitfield
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -51,6 +54,82 @@ func TestNewBitlist(t *testing.T) {
 	}
 }
 
+func TestBitlistFromSSZ(t *testing.T) {
+	tests := []struct {
+		data    []byte
+		limit   uint64
+		want    Bitlist
+		wantErr error
+	}{
+		{
+			data:    []byte{},
+			limit:   8,
+			wantErr: ErrBitlistMissingDelimiter,
+		},
+		{
+			data:    []byte{0x00},
+			limit:   8,
+			wantErr: ErrBitlistMissingDelimiter,
+		},
+		{
+			data:    []byte{0x00, 0x00},
+			limit:   8,
+			wantErr: ErrBitlistMissingDelimiter,
+		},
+		{
+			data:    []byte{0x0b, 0x00},
+			limit:   8,
+			wantErr: ErrBitlistTrailingBytes,
+		},
+		{
+			data:    []byte{0xff, 0x01, 0x00},
+			limit:   16,
+			wantErr: ErrBitlistTrailingBytes,
+		},
+		{
+			data:    []byte{0xff, 0x01},
+			limit:   7,
+			wantErr: ErrBitlistExceedsLimit,
+		},
+		{
+			data:    []byte{0x00, 0x00, 0x01},
+			limit:   15,
+			wantErr: ErrBitlistExceedsLimit,
+		},
+		{
+			data:  []byte{0x01},
+			limit: 0,
+			want:  Bitlist{0x01},
+		},
+		{
+			data:  []byte{0x0b},
+			limit: 3,
+			want:  Bitlist{0x0b},
+		},
+		{
+			data:  []byte{0xff, 0x01},
+			limit: 8,
+			want:  Bitlist{0xff, 0x01},
+		},
+		{
+			data:  []byte{0x00, 0x00, 0x01},
+			limit: 2048,
+			want:  Bitlist{0x00, 0x00, 0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := BitlistFromSSZ(tt.data, tt.limit)
+		if err != tt.wantErr {
+			t.Errorf("BitlistFromSSZ(%#x, %d) error = %v, wanted %v", tt.data, tt.limit, err, tt.wantErr)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("BitlistFromSSZ(%#x, %d) = %#x, wanted %#x", tt.data, tt.limit, got, tt.want)
+		}
+	}
+}
+
 func TestBitlist_Len(t *testing.T) {
 	tests := []struct {
 		bitlist Bitlist
@@ -788,7 +867,23 @@ func TestBitlist_Or(t *testing.T) {
 				}
 			}
 		})
+
+		t.Run("OrCount()", func(t *testing.T) {
+			if got, err := tt.a.OrCount(tt.b); got != tt.want.Count() || err != nil {
+				t.Errorf("(%x).OrCount(%x) = %d, %v, wanted %d", tt.a, tt.b, got, err, tt.want.Count())
+			}
+		})
 	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := NewBitlist(8), NewBitlist(9)
+		if _, err := a.OrCount(b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if err := a.NoAllocOr(a, b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+	})
 }
 
 func TestBitlist_And(t *testing.T) {
@@ -850,7 +945,28 @@ func TestBitlist_And(t *testing.T) {
 				tt.want,
 			)
 		}
+		res := tt.a.Clone()
+		// Make sure that no existing bits set interfere with operation.
+		for i := uint64(0); i < res.Len(); i += 3 {
+			res.SetBitAt(i, true)
+		}
+		if err := tt.a.NoAllocAnd(tt.b, res); !bytes.Equal(res, tt.want) || err != nil {
+			t.Errorf("(%x).NoAllocAnd(%x) = %x, %v, wanted %x", tt.a, tt.b, res, err, tt.want)
+		}
+		if got, err := tt.a.AndCount(tt.b); got != tt.want.Count() || err != nil {
+			t.Errorf("(%x).AndCount(%x) = %d, %v, wanted %d", tt.a, tt.b, got, err, tt.want.Count())
+		}
 	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := NewBitlist(8), NewBitlist(9)
+		if _, err := a.AndCount(b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if err := a.NoAllocAnd(a, b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+	})
 }
 
 func TestBitlist_Xor(t *testing.T) {
@@ -913,8 +1029,100 @@ func TestBitlist_Xor(t *testing.T) {
 					tt.want,
 				)
 			}
+			res := tt.a.Clone()
+			for i := uint64(0); i < res.Len(); i += 3 {
+				res.SetBitAt(i, true)
+			}
+			if err := tt.a.NoAllocXor(tt.b, res); !bytes.Equal(res, tt.want) || err != nil {
+				t.Errorf("(%x).NoAllocXor(%x) = %x, %v, wanted %x", tt.a, tt.b, res, err, tt.want)
+			}
+			if got, err := tt.a.XorCount(tt.b); got != tt.want.Count() || err != nil {
+				t.Errorf("(%x).XorCount(%x) = %d, %v, wanted %d", tt.a, tt.b, got, err, tt.want.Count())
+			}
+		})
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := NewBitlist(8), NewBitlist(9)
+		if _, err := a.XorCount(b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if err := a.NoAllocXor(a, b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+	})
+}
+
+func TestBitlist_AndNot(t *testing.T) {
+	tests := []struct {
+		a    Bitlist
+		b    Bitlist
+		want Bitlist
+	}{
+		{
+			a:    Bitlist{0x01}, // Empty bitlist.
+			b:    Bitlist{0x01},
+			want: Bitlist{0x01},
+		},
+		{
+			a:    Bitlist{0x02}, // 0b00000010
+			b:    Bitlist{0x03}, // 0b00000011
+			want: Bitlist{0x02}, // 0b00000010
+		},
+		{
+			a:    Bitlist{0x13}, // 0b00010011
+			b:    Bitlist{0x15}, // 0b00010101
+			want: Bitlist{0x12}, // 0b00010010
+		},
+		{
+			a:    Bitlist{0x1F}, // 0b00011111
+			b:    Bitlist{0x13}, // 0b00010011
+			want: Bitlist{0x1c}, // 0b00011100
+		},
+		{
+			a:    Bitlist{0x1F, 0x03}, // 0b00011111, 0b00000011
+			b:    Bitlist{0x13, 0x02}, // 0b00010011, 0b00000010
+			want: Bitlist{0x0c, 0x03}, // 0b00001100, 0b00000011
+		},
+		{
+			a:    Bitlist{0xFF, 0x87}, // 0b11111111, 0x10000111
+			b:    Bitlist{0x13, 0x8F}, // 0b00010011, 0x10001111
+			want: Bitlist{0xec, 0x80}, // 0b11101100, 0x10000000
+		},
+		{
+			a:    Bitlist{0xFF, 0x01}, // Length bit in a separate byte.
+			b:    Bitlist{0x0F, 0x01},
+			want: Bitlist{0xF0, 0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("(%x).AndNot(%x)", tt.a, tt.b), func(t *testing.T) {
+			if got, err := tt.a.AndNot(tt.b); !bytes.Equal(got, tt.want) || err != nil {
+				t.Errorf("(%x).AndNot(%x) = %x, %v, wanted %x", tt.a, tt.b, got, err, tt.want)
+			}
+			ret := NewBitlist(tt.a.Len())
+			if err := tt.a.NoAllocAndNot(tt.b, ret); !bytes.Equal(ret, tt.want) || err != nil {
+				t.Errorf("(%x).NoAllocAndNot(%x) = %x, %v, wanted %x", tt.a, tt.b, ret, err, tt.want)
+			}
+			if got, err := tt.a.AndNotCount(tt.b); got != tt.want.Count() || err != nil {
+				t.Errorf("(%x).AndNotCount(%x) = %d, %v, wanted %d", tt.a, tt.b, got, err, tt.want.Count())
+			}
 		})
 	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := NewBitlist(8), NewBitlist(9)
+		if _, err := a.AndNot(b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if err := a.NoAllocAndNot(a, b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if _, err := a.AndNotCount(b); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+	})
 }
 
 func TestBitlist_Not(t *testing.T) {
@@ -986,41 +1194,1080 @@ func TestBitlist_Not(t *testing.T) {
 					tt.want,
 				)
 			}
+			res := tt.a.Clone()
+			if err := tt.a.NoAllocNot(res); !bytes.Equal(res, tt.want) || err != nil {
+				t.Errorf("(%x).NoAllocNot() = %x, %v, wanted %x", tt.a, res, err, tt.want)
+			}
 		})
 	}
+
+	t.Run("check errors", func(t *testing.T) {
+		if err := NewBitlist(8).NoAllocNot(NewBitlist(9)); err != ErrBitlistDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+	})
 }
 
-func TestBitlist_BitIndices(t *testing.T) {
+func TestBitlist_InPlace(t *testing.T) {
 	tests := []struct {
-		a    Bitlist
-		want []int
+		a Bitlist
+		b Bitlist
+	}{
+		{a: Bitlist{0x01}, b: Bitlist{0x01}},
+		{a: Bitlist{0x13}, b: Bitlist{0x15}},
+		{a: Bitlist{0xFF, 0x01}, b: Bitlist{0x0F, 0x01}},
+		{a: Bitlist{0xFF, 0x87}, b: Bitlist{0x13, 0x8F}},
+	}
+
+	for _, tt := range tests {
+		for _, op := range []struct {
+			name    string
+			inPlace func(a, b Bitlist) error
+			alloc   func(a, b Bitlist) (Bitlist, error)
+		}{
+			{"Or", Bitlist.OrInPlace, Bitlist.Or},
+			{"And", Bitlist.AndInPlace, Bitlist.And},
+			{"Xor", Bitlist.XorInPlace, Bitlist.Xor},
+			{"AndNot", Bitlist.AndNotInPlace, Bitlist.AndNot},
+		} {
+			want, err := op.alloc(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := tt.a.Clone()
+			if err := op.inPlace(got, tt.b); err != nil || !bytes.Equal(got, want) {
+				t.Errorf("(%x).%sInPlace(%x) = %x, %v, wanted %x", tt.a, op.name, tt.b, got, err, want)
+			}
+
+			// The receiver and the argument may be the same bitlist.
+			want, err = op.alloc(tt.a, tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = tt.a.Clone()
+			if err := op.inPlace(got, got); err != nil || !bytes.Equal(got, want) {
+				t.Errorf("(%x).%sInPlace(itself) = %x, %v, wanted %x", tt.a, op.name, got, err, want)
+			}
+		}
+
+		got := tt.a.Clone()
+		got.NotInPlace()
+		if want := tt.a.Not(); !bytes.Equal(got, want) {
+			t.Errorf("(%x).NotInPlace() = %x, wanted %x", tt.a, got, want)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := NewBitlist(8), NewBitlist(9)
+		for _, fn := range []func(Bitlist) error{a.OrInPlace, a.AndInPlace, a.XorInPlace, a.AndNotInPlace} {
+			if err := fn(b); err != ErrBitlistDifferentLength {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+			}
+		}
+	})
+}
+
+func TestBitlist_Shift(t *testing.T) {
+	tests := []struct {
+		bitlist Bitlist
+		shift   int
+		want    Bitlist
 	}{
 		{
-			a:    Bitlist{0b10010},
-			want: []int{1},
+			bitlist: Bitlist{0x01},
+			shift:   1,
+			want:    Bitlist{0x01},
 		},
 		{
-			a:    Bitlist{0b10000},
-			want: []int{},
+			bitlist: Bitlist{0x01},
+			shift:   -1,
+			want:    Bitlist{0x01},
 		},
 		{
-			a:    Bitlist{0b10, 0b1},
-			want: []int{1},
+			bitlist: Bitlist{0x01},
+			shift:   3,
+			want:    Bitlist{0x01},
 		},
 		{
-			a:    Bitlist{0b11111111, 0b11},
-			want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			bitlist: Bitlist{0x01},
+			shift:   -3,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   8,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   -9,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   30,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   -30,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   1,
+			want:    Bitlist{0x0e},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -1,
+			want:    Bitlist{0x09},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   3,
+			want:    Bitlist{0x08},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -3,
+			want:    Bitlist{0x08},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   8,
+			want:    Bitlist{0x08},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -9,
+			want:    Bitlist{0x08},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   30,
+			want:    Bitlist{0x08},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -30,
+			want:    Bitlist{0x08},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   1,
+			want:    Bitlist{0x1a},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -1,
+			want:    Bitlist{0x16},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   3,
+			want:    Bitlist{0x18},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -3,
+			want:    Bitlist{0x11},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   8,
+			want:    Bitlist{0x10},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -9,
+			want:    Bitlist{0x10},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   30,
+			want:    Bitlist{0x10},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -30,
+			want:    Bitlist{0x10},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   1,
+			want:    Bitlist{0x4a, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -1,
+			want:    Bitlist{0x52, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   3,
+			want:    Bitlist{0x28, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -3,
+			want:    Bitlist{0x14, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   8,
+			want:    Bitlist{0x00, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -9,
+			want:    Bitlist{0x00, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   30,
+			want:    Bitlist{0x00, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -30,
+			want:    Bitlist{0x00, 0x01},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   1,
+			want:    Bitlist{0x02, 0x79, 0x06},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -1,
+			want:    Bitlist{0x40, 0x9e, 0x04},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   3,
+			want:    Bitlist{0x08, 0xe4, 0x05},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -3,
+			want:    Bitlist{0x90, 0x27, 0x04},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   8,
+			want:    Bitlist{0x00, 0x81, 0x04},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -9,
+			want:    Bitlist{0x9e, 0x00, 0x04},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   30,
+			want:    Bitlist{0x00, 0x00, 0x04},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -30,
+			want:    Bitlist{0x00, 0x00, 0x04},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   1,
+			want:    Bitlist{0xfe, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -1,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x5f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   3,
+			want:    Bitlist{0xf8, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -3,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x47},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   8,
+			want:    Bitlist{0x00, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -9,
+			want:    Bitlist{0xff, 0xff, 0x1f, 0x40},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   30,
+			want:    Bitlist{0x00, 0x00, 0x00, 0x40},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -30,
+			want:    Bitlist{0x00, 0x00, 0x00, 0x40},
 		},
 	}
 
 	for _, tt := range tests {
-		if !reflect.DeepEqual(tt.a.BitIndices(), tt.want) {
-			t.Errorf(
-				"(%0.8b).BitIndices() = %x, wanted %x",
-				tt.a,
-				tt.a.BitIndices(),
-				tt.want,
-			)
+		original := make(Bitlist, len(tt.bitlist))
+		copy(original, tt.bitlist)
+
+		tt.bitlist.Shift(tt.shift)
+		if !bytes.Equal(tt.bitlist, tt.want) {
+			t.Errorf("(%#x).Shift(%d) = %#x, wanted %#x", original, tt.shift, tt.bitlist, tt.want)
 		}
 	}
 }
+
+func TestBitlist_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitlist Bitlist
+		shift   int
+		want    Bitlist
+	}{
+		{
+			bitlist: Bitlist{0x01},
+			shift:   1,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   -1,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   3,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   9,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   -17,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   1,
+			want:    Bitlist{0x0e},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -1,
+			want:    Bitlist{0x0d},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   3,
+			want:    Bitlist{0x0b},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   9,
+			want:    Bitlist{0x0b},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -17,
+			want:    Bitlist{0x0e},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   1,
+			want:    Bitlist{0x1b},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -1,
+			want:    Bitlist{0x1e},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   3,
+			want:    Bitlist{0x1e},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   9,
+			want:    Bitlist{0x1b},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -17,
+			want:    Bitlist{0x1e},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   1,
+			want:    Bitlist{0x4b, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -1,
+			want:    Bitlist{0xd2, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   3,
+			want:    Bitlist{0x2d, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   9,
+			want:    Bitlist{0x4b, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -17,
+			want:    Bitlist{0xd2, 0x01},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   1,
+			want:    Bitlist{0x02, 0x79, 0x06},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -1,
+			want:    Bitlist{0x40, 0x9e, 0x06},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   3,
+			want:    Bitlist{0x0a, 0xe4, 0x05},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   9,
+			want:    Bitlist{0x9e, 0x02, 0x05},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -17,
+			want:    Bitlist{0x02, 0x79, 0x06},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   1,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -1,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   3,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   9,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -17,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitlist, len(tt.bitlist))
+		copy(original, tt.bitlist)
+
+		tt.bitlist.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitlist, tt.want) {
+			t.Errorf("(%#x).RotateLeft(%d) = %#x, wanted %#x", original, tt.shift, tt.bitlist, tt.want)
+		}
+	}
+}
+
+func TestBitlist_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitlist Bitlist
+		shift   int
+		want    Bitlist
+	}{
+		{
+			bitlist: Bitlist{0x01},
+			shift:   1,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   -1,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   3,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   9,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x01},
+			shift:   -17,
+			want:    Bitlist{0x01},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   1,
+			want:    Bitlist{0x0d},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -1,
+			want:    Bitlist{0x0e},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   3,
+			want:    Bitlist{0x0b},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   9,
+			want:    Bitlist{0x0b},
+		},
+		{
+			bitlist: Bitlist{0x0b},
+			shift:   -17,
+			want:    Bitlist{0x0d},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   1,
+			want:    Bitlist{0x1e},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -1,
+			want:    Bitlist{0x1b},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   3,
+			want:    Bitlist{0x1b},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   9,
+			want:    Bitlist{0x1e},
+		},
+		{
+			bitlist: Bitlist{0x1d},
+			shift:   -17,
+			want:    Bitlist{0x1b},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   1,
+			want:    Bitlist{0xd2, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -1,
+			want:    Bitlist{0x4b, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   3,
+			want:    Bitlist{0xb4, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   9,
+			want:    Bitlist{0xd2, 0x01},
+		},
+		{
+			bitlist: Bitlist{0xa5, 0x01},
+			shift:   -17,
+			want:    Bitlist{0x4b, 0x01},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   1,
+			want:    Bitlist{0x40, 0x9e, 0x06},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -1,
+			want:    Bitlist{0x02, 0x79, 0x06},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   3,
+			want:    Bitlist{0x90, 0xa7, 0x04},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   9,
+			want:    Bitlist{0x9e, 0x02, 0x05},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x3c, 0x05},
+			shift:   -17,
+			want:    Bitlist{0x40, 0x9e, 0x06},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   1,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -1,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   3,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   9,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+		{
+			bitlist: Bitlist{0xff, 0xff, 0xff, 0x7f},
+			shift:   -17,
+			want:    Bitlist{0xff, 0xff, 0xff, 0x7f},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitlist, len(tt.bitlist))
+		copy(original, tt.bitlist)
+
+		tt.bitlist.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitlist, tt.want) {
+			t.Errorf("(%#x).RotateRight(%d) = %#x, wanted %#x", original, tt.shift, tt.bitlist, tt.want)
+		}
+	}
+}
+
+func TestBitlist_All(t *testing.T) {
+	tests := []struct {
+		bitlist   Bitlist
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitlist:   Bitlist{0x01},
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+		{
+			bitlist:   Bitlist{0x0b},
+			want:      []uint64{0, 1},
+			wantUnset: []uint64{2},
+		},
+		{
+			bitlist:   Bitlist{0x80},
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			bitlist:   Bitlist{0xa5, 0x01},
+			want:      []uint64{0, 2, 5, 7},
+			wantUnset: []uint64{1, 3, 4, 6},
+		},
+		{
+			bitlist:   Bitlist{0x00, 0xff, 0x03},
+			want:      []uint64{8, 9, 10, 11, 12, 13, 14, 15, 16},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			bitlist:   Bitlist{0xff, 0xff, 0xff, 0x7f},
+			want:      []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29},
+			wantUnset: []uint64{},
+		},
+		{
+			bitlist:   Bitlist{},
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitlist.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitlist, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitlist.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitlist, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range (Bitlist{0xff, 0x01}).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitlist_RankSelect(t *testing.T) {
+	tests := []struct {
+		bitlist Bitlist
+		ranks   []uint64 // Rank of every index in [0, Len()].
+		selects []uint64 // Select of every k in [0, Count()).
+	}{
+		{
+			bitlist: Bitlist{0x01},
+			ranks:   []uint64{0},
+			selects: []uint64{},
+		},
+		{
+			bitlist: Bitlist{0x08},
+			ranks:   []uint64{0, 0, 0, 0},
+			selects: []uint64{},
+		},
+		{
+			bitlist: Bitlist{0x35}, // 0b00110101, bits=[1,0,1,0,1]
+			ranks:   []uint64{0, 1, 1, 2, 2, 3},
+			selects: []uint64{0, 2, 4},
+		},
+		{
+			bitlist: Bitlist{0x81, 0x06}, // Length bit must not be counted.
+			ranks:   []uint64{0, 1, 1, 1, 1, 1, 1, 1, 2, 2, 3},
+			selects: []uint64{0, 7, 9},
+		},
+	}
+
+	for _, tt := range tests {
+		for idx, want := range tt.ranks {
+			if got := tt.bitlist.Rank(uint64(idx)); got != want {
+				t.Errorf("(%x).Rank(%d) = %d, wanted %d", tt.bitlist, idx, got, want)
+			}
+		}
+		for k, want := range tt.selects {
+			if got, ok := tt.bitlist.Select(uint64(k)); !ok || got != want {
+				t.Errorf("(%x).Select(%d) = %d, %t, wanted %d, true", tt.bitlist, k, got, ok, want)
+			}
+		}
+		if got, ok := tt.bitlist.Select(uint64(len(tt.selects))); ok {
+			t.Errorf("(%x).Select(%d) = %d, true, wanted false", tt.bitlist, len(tt.selects), got)
+		}
+		if got := tt.bitlist.Rank(tt.bitlist.Len() + 100); got != tt.bitlist.Count() {
+			t.Errorf("(%x).Rank(%d) = %d, wanted %d", tt.bitlist, tt.bitlist.Len()+100, got, tt.bitlist.Count())
+		}
+	}
+}
+
+func TestBitlist_NextPrev(t *testing.T) {
+	tests := []struct {
+		bitlist Bitlist
+		from    uint64
+		nextSet uint64
+		prevSet uint64
+		ok      bool
+	}{
+		{
+			bitlist: Bitlist{0x01},
+			from:    0,
+			ok:      false,
+		},
+		{
+			// Length bit at index 3 must not be reported as set.
+			bitlist: Bitlist{0x09}, // 0b00001001, bits=[1,0,0]
+			from:    1,
+			nextSet: 0,
+			ok:      false,
+		},
+		{
+			bitlist: Bitlist{0x81, 0x06}, // bits=[1,0,0,0,0,0,0,1,0,1]
+			from:    8,
+			nextSet: 9,
+			prevSet: 7,
+			ok:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		if idx, ok := tt.bitlist.NextSet(tt.from); ok != tt.ok || idx != tt.nextSet {
+			t.Errorf("(%x).NextSet(%d) = %d, %t, wanted %d, %t", tt.bitlist, tt.from, idx, ok, tt.nextSet, tt.ok)
+		}
+		if tt.ok {
+			if idx, ok := tt.bitlist.PrevSet(tt.from); !ok || idx != tt.prevSet {
+				t.Errorf("(%x).PrevSet(%d) = %d, %t, wanted %d, true", tt.bitlist, tt.from, idx, ok, tt.prevSet)
+			}
+		}
+	}
+
+	for _, b := range []Bitlist{
+		{0x01}, {0x02}, {0x03}, {0x09}, {0xff, 0x01}, {0x00, 0x02}, {0x81, 0x06}, {0x55, 0xaa, 0x03},
+		{0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 0x80},
+	} {
+		checkNextPrev(t, b)
+	}
+}
+
+func TestBitlist_BitIndices(t *testing.T) {
+	tests := []struct {
+		a    Bitlist
+		want []int
+	}{
+		{
+			a:    Bitlist{0b10010},
+			want: []int{1},
+		},
+		{
+			a:    Bitlist{0b10000},
+			want: []int{},
+		},
+		{
+			a:    Bitlist{0b10, 0b1},
+			want: []int{1},
+		},
+		{
+			a:    Bitlist{0b11111111, 0b11},
+			want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+		},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.a.BitIndices(), tt.want) {
+			t.Errorf(
+				"(%0.8b).BitIndices() = %x, wanted %x",
+				tt.a,
+				tt.a.BitIndices(),
+				tt.want,
+			)
+		}
+		indices := make([]int, tt.a.Count())
+		tt.a.NoAllocBitIndices(indices)
+		if !reflect.DeepEqual(indices, tt.want) {
+			t.Errorf("(%0.8b).NoAllocBitIndices() = %x, wanted %x", tt.a, indices, tt.want)
+		}
+	}
+
+	t.Run("capped by ret", func(t *testing.T) {
+		indices := make([]int, 2)
+		Bitlist{0b11111111, 0b11}.NoAllocBitIndices(indices)
+		if !reflect.DeepEqual(indices, []int{0, 1}) {
+			t.Errorf("NoAllocBitIndices() = %v, wanted %v", indices, []int{0, 1})
+		}
+	})
+}
+
+func TestBitlist_Clone(t *testing.T) {
+	a := Bitlist{0x13, 0x02}
+	c := a.Clone()
+	if !bytes.Equal(a, c) {
+		t.Errorf("(%x).Clone() = %x, wanted %x", a, c, a)
+	}
+	c.SetBitAt(2, true)
+	if a.BitAt(2) {
+		t.Error("Clone() shares the underlying array with the original bitlist")
+	}
+	if Bitlist(nil).Clone() != nil {
+		t.Error("Clone() of a nil bitlist is not nil")
+	}
+}
+
+func TestBitlist_Resize(t *testing.T) {
+	tests := []struct {
+		a    Bitlist
+		n    uint64
+		want Bitlist
+	}{
+		{
+			a:    Bitlist{},
+			n:    3,
+			want: Bitlist{0x08},
+		},
+		{
+			a:    Bitlist{0x1d},
+			n:    8,
+			want: Bitlist{0x0d, 0x01},
+		},
+		{
+			a:    Bitlist{0x0d, 0x01},
+			n:    2,
+			want: Bitlist{0x05},
+		},
+		{
+			a:    Bitlist{0xff, 0x03},
+			n:    8,
+			want: Bitlist{0xff, 0x01},
+		},
+		{
+			a:    Bitlist{0xff, 0x03},
+			n:    0,
+			want: Bitlist{0x01},
+		},
+		{
+			a:    Bitlist{0x13, 0x02},
+			n:    20,
+			want: Bitlist{0x13, 0x00, 0x10},
+		},
+		{
+			a:    Bitlist{0x13, 0x02},
+			n:    9,
+			want: Bitlist{0x13, 0x02},
+		},
+	}
+
+	for _, tt := range tests {
+		a := tt.a.Clone()
+		if got := a.Resize(tt.n); !bytes.Equal(got, tt.want) {
+			t.Errorf("(%x).Resize(%d) = %x, wanted %x", tt.a, tt.n, got, tt.want)
+		}
+	}
+
+	t.Run("truncate", func(t *testing.T) {
+		if got := (Bitlist{0x13, 0x02}).Truncate(20); !bytes.Equal(got, Bitlist{0x13, 0x02}) {
+			t.Errorf("Truncate(20) = %x, wanted 1302", got)
+		}
+		if got := (Bitlist{0x13, 0x02}).Truncate(4); !bytes.Equal(got, Bitlist{0x13}) {
+			t.Errorf("Truncate(4) = %x, wanted 13", got)
+		}
+	})
+
+	t.Run("append", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		var want []bool
+		b := NewBitlist(0)
+		for i := 0; i < 100; i++ {
+			if r.Intn(2) == 0 {
+				val := r.Intn(2) == 0
+				b = b.Append(val)
+				want = append(want, val)
+			} else {
+				other := NewBitlist(uint64(r.Intn(20)))
+				for j := uint64(0); j < other.Len(); j++ {
+					val := r.Intn(2) == 0
+					other.SetBitAt(j, val)
+					want = append(want, val)
+				}
+				b = b.AppendBits(other)
+			}
+		}
+		b = b.AppendBits(b)
+		want = append(want, want...)
+
+		if b.Len() != uint64(len(want)) {
+			t.Fatalf("Len() = %d, wanted %d", b.Len(), len(want))
+		}
+		if len(b) != len(want)/8+1 {
+			t.Errorf("len(b) = %d, wanted %d", len(b), len(want)/8+1)
+		}
+		for i, val := range want {
+			if b.BitAt(uint64(i)) != val {
+				t.Fatalf("BitAt(%d) = %t, wanted %t", i, !val, val)
+			}
+		}
+	})
+
+	t.Run("append overlapping", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			bFrom, bTo int
+			oFrom, oTo int
+		}{
+			{name: "other inside b", bFrom: 0, bTo: 3, oFrom: 1, oTo: 3},
+			{name: "other is last byte of b", bFrom: 0, bTo: 3, oFrom: 2, oTo: 3},
+			{name: "other in spare capacity of b", bFrom: 2, bTo: 3, oFrom: 3, oTo: 5},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := make([]byte, 8)
+				copy(buf, []byte{0xa5, 0x3c, 0x01, 0xff, 0x01})
+				b, other := Bitlist(buf[tt.bFrom:tt.bTo]), Bitlist(buf[tt.oFrom:tt.oTo])
+				want := b.Clone().AppendBits(other.Clone())
+				if got := b.AppendBits(other); !bytes.Equal(got, want) {
+					t.Errorf("AppendBits() = %x, wanted %x", got, want)
+				}
+			})
+		}
+	})
+}
+
+func TestBitlist_SliceConcat(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b := NewBitlist(100)
+	for i := uint64(0); i < b.Len(); i++ {
+		b.SetBitAt(i, r.Intn(2) == 0)
+	}
+
+	for from := uint64(0); from <= b.Len(); from += 7 {
+		for to := from; to <= b.Len(); to += 5 {
+			got, err := b.Slice(from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := NewBitlist(0)
+			for i := from; i < to; i++ {
+				want = want.Append(b.BitAt(i))
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Slice(%d, %d) = %x, wanted %x", from, to, got, want)
+			}
+
+			// Splitting the bitlist and joining the parts back must give the original.
+			head, _ := b.Slice(0, from)
+			tail, _ := b.Slice(to, b.Len())
+			if joined := head.Concat(got, tail); !bytes.Equal(joined, b) {
+				t.Errorf("Concat() of the parts split at %d, %d = %x, wanted %x", from, to, joined, b)
+			}
+		}
+	}
+
+	t.Run("concat nothing", func(t *testing.T) {
+		if got := (Bitlist{0x13}).Concat(); !bytes.Equal(got, Bitlist{0x13}) {
+			t.Errorf("Concat() = %x, wanted 13", got)
+		}
+		if got := Bitlist(nil).Concat(Bitlist{0x01}); !bytes.Equal(got, Bitlist{0x01}) {
+			t.Errorf("Concat() = %x, wanted 01", got)
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, rng := range [][2]uint64{{0, 101}, {50, 49}, {101, 101}} {
+			if _, err := b.Slice(rng[0], rng[1]); !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("Slice(%d, %d): wrong error returned. Wanted %v, got %v", rng[0], rng[1], ErrInvalidRange, err)
+			}
+		}
+	})
+}
+
+func TestBitlist_Range(t *testing.T) {
+	for _, n := range []uint64{0, 1, 7, 8, 9, 100} {
+		checkRangeOps(t, NewBitlist(n))
+	}
+}
+
+func TestBitlist_TryBitAt(t *testing.T) {
+	for _, n := range []uint64{0, 1, 8, 100} {
+		checkStrictAccessors(t, NewBitlist(n))
+	}
+
+	err := error(&ErrIndexOutOfRange{Index: 9, Len: 8})
+	if want := "index 9 out of range for bitfield of length 8"; err.Error() != want {
+		t.Errorf("Error() = %q, wanted %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,293 @@
+package bitfield
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// This file holds helpers shared by the byte backed bitfields (Bitlist and BitvectorN). They
+// operate on the first n bits of a little endian byte array, the same indexing used by BitAt.
+// Bits past n in the last byte (such as the Bitlist length bit) are left untouched.
+
+// shiftBits shifts the first n bits of b by i. If i >= 0, bits move towards higher indices (bit k
+// becomes bit k+i), otherwise they move towards lower indices. Bits shifted past either end are
+// dropped, and vacated bits are set to zero.
+func shiftBits(b []byte, n uint64, i int) {
+	if i >= 0 {
+		shiftBitsUp(b, n, uint64(i))
+	} else {
+		shiftBitsDown(b, n, uint64(-(i+1))+1)
+	}
+}
+
+// rotateBits rotates the first n bits of b by s towards higher indices i.e. bit k becomes bit
+// (k+s) mod n.
+func rotateBits(b []byte, n, s uint64) {
+	if n == 0 || s%n == 0 {
+		return
+	}
+	s %= n
+
+	numBytes := (n + 7) >> 3
+	wrapped := make([]byte, numBytes)
+	copy(wrapped, b[:numBytes])
+	shiftBitsUp(b, n, s)
+	shiftBitsDown(wrapped, n, n-s)
+	for i, bt := range wrapped {
+		b[i] |= bt
+	}
+}
+
+// rotation returns the rotation of n bits by i towards higher indices as an amount in [0, n),
+// where a negative i rotates towards lower indices.
+func rotation(n uint64, i int) uint64 {
+	if n == 0 {
+		return 0
+	}
+	if i >= 0 {
+		return uint64(i) % n
+	}
+	return (n - (uint64(-(i+1))+1)%n) % n
+}
+
+// shiftBitsUp moves the first n bits of b by s towards higher indices.
+func shiftBitsUp(b []byte, n, s uint64) {
+	numBytes := (n + 7) >> 3
+	if numBytes == 0 {
+		return
+	}
+	if s > n {
+		s = n
+	}
+
+	last := b[numBytes-1]
+	byteShift, bitShift := s>>3, s%8
+	for j := numBytes; j > 0; j-- {
+		dst := j - 1
+		var v byte
+		if dst >= byteShift {
+			v = b[dst-byteShift] << bitShift
+			if bitShift > 0 && dst > byteShift {
+				v |= b[dst-byteShift-1] >> (8 - bitShift)
+			}
+		}
+		b[dst] = v
+	}
+	restoreHighBits(b, n, last)
+}
+
+// shiftBitsDown moves the first n bits of b by s towards lower indices.
+func shiftBitsDown(b []byte, n, s uint64) {
+	numBytes := (n + 7) >> 3
+	if numBytes == 0 {
+		return
+	}
+	if s > n {
+		s = n
+	}
+
+	// Clear the bits past n first, so they are not shifted into the bitfield.
+	last := b[numBytes-1]
+	restoreHighBits(b, n, 0)
+
+	byteShift, bitShift := s>>3, s%8
+	for dst := uint64(0); dst < numBytes; dst++ {
+		var v byte
+		if src := dst + byteShift; src < numBytes {
+			v = b[src] >> bitShift
+			if bitShift > 0 && src+1 < numBytes {
+				v |= b[src+1] << (8 - bitShift)
+			}
+		}
+		b[dst] = v
+	}
+	restoreHighBits(b, n, last)
+}
+
+// restoreHighBits sets the bits past n in the last byte holding the bitfield to those found in
+// orig, leaving the bits below n untouched.
+func restoreHighBits(b []byte, n uint64, orig byte) {
+	if n%8 == 0 {
+		return
+	}
+	mask := byte(0xff) << (n % 8)
+	b[n>>3] = b[n>>3]&^mask | orig&mask
+}
+
+// bitsSeq returns an iterator over the indices of the first n bits of b which are set to 1 or, if
+// unset is true, to 0. Bytes are scanned one at a time, so only the matching bits are visited.
+func bitsSeq(b []byte, n uint64, unset bool) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		numBytes := (n + 7) >> 3
+		for i := uint64(0); i < numBytes; i++ {
+			bt := b[i]
+			if unset {
+				bt = ^bt
+			}
+			if i == numBytes-1 && n%8 != 0 {
+				// Ignore the bits past n, such as the length bit of a bitlist.
+				bt &= 0xff >> (8 - n%8)
+			}
+			for bt != 0 {
+				if !yield(i<<3 + uint64(bits.TrailingZeros8(bt))) {
+					return
+				}
+				// Clear the lowest set bit.
+				bt &= bt - 1
+			}
+		}
+	}
+}
+
+// rankBits returns the number of bits set to 1 among the first min(idx, n) bits of b.
+func rankBits(b []byte, n, idx uint64) uint64 {
+	if idx > n {
+		idx = n
+	}
+	c := 0
+	for _, bt := range b[:idx>>3] {
+		c += bits.OnesCount8(bt)
+	}
+	if idx%8 != 0 {
+		c += bits.OnesCount8(b[idx>>3] & (0xff >> (8 - idx%8)))
+	}
+	return uint64(c)
+}
+
+// selectBits returns the index of the k-th (counting from zero) bit set to 1 among the first n
+// bits of b. The second return value is false if there are not enough bits set.
+func selectBits(b []byte, n, k uint64) (uint64, bool) {
+	numBytes := (n + 7) >> 3
+	for i := uint64(0); i < numBytes; i++ {
+		bt := b[i]
+		if i == numBytes-1 && n%8 != 0 {
+			bt &= 0xff >> (8 - n%8)
+		}
+		c := uint64(bits.OnesCount8(bt))
+		if k < c {
+			return i<<3 + uint64(selectInWord(uint64(bt), int(k))), true
+		}
+		k -= c
+	}
+	return 0, false
+}
+
+// selectInWord returns the position of the k-th (counting from zero) bit set to 1 in the word,
+// which must have more than k bits set.
+func selectInWord(word uint64, k int) int {
+	for ; k > 0; k-- {
+		// Clear the lowest set bit.
+		word &= word - 1
+	}
+	return bits.TrailingZeros64(word)
+}
+
+// nextBit returns the lowest index in [from, n) of a bit in b which is set to 1 or, if unset is
+// true, to 0. The second return value is false if there is no such bit.
+func nextBit(b []byte, n, from uint64, unset bool) (uint64, bool) {
+	if from >= n {
+		return 0, false
+	}
+	numBytes := (n + 7) >> 3
+	i := from >> 3
+	bt := maskedByte(b, n, i, unset) & (0xff << (from % 8))
+	for bt == 0 {
+		i++
+		if i >= numBytes {
+			return 0, false
+		}
+		bt = maskedByte(b, n, i, unset)
+	}
+	return i<<3 + uint64(bits.TrailingZeros8(bt)), true
+}
+
+// prevBit returns the highest index in [0, min(from, n-1)] of a bit in b which is set to 1 or, if
+// unset is true, to 0. The second return value is false if there is no such bit.
+func prevBit(b []byte, n, from uint64, unset bool) (uint64, bool) {
+	if n == 0 {
+		return 0, false
+	}
+	if from >= n {
+		from = n - 1
+	}
+	i := from >> 3
+	bt := maskedByte(b, n, i, unset) & (0xff >> (7 - from%8))
+	for bt == 0 {
+		if i == 0 {
+			return 0, false
+		}
+		i--
+		bt = maskedByte(b, n, i, unset)
+	}
+	return i<<3 + 7 - uint64(bits.LeadingZeros8(bt)), true
+}
+
+// maskedByte returns the i-th byte of b, inverted if unset is true, with the bits past n cleared.
+func maskedByte(b []byte, n, i uint64, unset bool) byte {
+	bt := b[i]
+	if unset {
+		bt = ^bt
+	}
+	if i == (n-1)>>3 && n%8 != 0 {
+		// Ignore the bits past n, such as the length bit of a bitlist.
+		bt &= 0xff >> (8 - n%8)
+	}
+	return bt
+}
+
+// checkRange returns an error unless [from, to) is a valid range of bits in a bitfield of n bits.
+func checkRange(from, to, n uint64) error {
+	if from > to || to > n {
+		return fmt.Errorf("%w: [%d, %d) of %d bits", ErrInvalidRange, from, to, n)
+	}
+	return nil
+}
+
+// rangeMask returns the bits of byte i which lie in the range [from, to).
+func rangeMask(i, from, to uint64) byte {
+	lo, mask := i<<3, byte(0xff)
+	if from > lo {
+		mask <<= from - lo
+	}
+	if to < lo+8 {
+		mask &= 0xff >> (lo + 8 - to)
+	}
+	return mask
+}
+
+// setRangeBits sets the bits in the range [from, to) to val.
+func setRangeBits(b []byte, from, to uint64, val bool) {
+	if from >= to {
+		return
+	}
+	for i := from >> 3; i <= (to-1)>>3; i++ {
+		if val {
+			b[i] |= rangeMask(i, from, to)
+		} else {
+			b[i] &^= rangeMask(i, from, to)
+		}
+	}
+}
+
+// flipRangeBits inverts the bits in the range [from, to).
+func flipRangeBits(b []byte, from, to uint64) {
+	if from >= to {
+		return
+	}
+	for i := from >> 3; i <= (to-1)>>3; i++ {
+		b[i] ^= rangeMask(i, from, to)
+	}
+}
+
+// countRangeBits returns the number of bits set to 1 in the range [from, to).
+func countRangeBits(b []byte, from, to uint64) uint64 {
+	if from >= to {
+		return 0
+	}
+	var cnt int
+	for i := from >> 3; i <= (to-1)>>3; i++ {
+		cnt += bits.OnesCount8(b[i] & rangeMask(i, from, to))
+	}
+	return uint64(cnt)
+}
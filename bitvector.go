@@ -0,0 +1,610 @@
+package bitfield
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Size is implemented by the types which parameterize Bitvector with its length in bits. Size
+// types are empty structs, so new sizes can be declared outside this package:
+//
+//	type Size100 struct{}
+//
+//	func (Size100) Bits() uint64 { return 100 }
+//
+//	type Bitvector100 = bitfield.Bitvector[Size100]
+type Size interface {
+	// Bits returns the number of bits in the bitvector.
+	Bits() uint64
+}
+
+// Bitvector is a bitfield with a fixed size given by S. There is no length bit present in the
+// underlying byte array, and the bits past the size in the last byte are padding, which must be
+// zero in the canonical encoding.
+//
+// The set operations such as Or and Contains only require their operands to be the same length,
+// and work on every byte of them. Methods which depend on the size, such as HashTreeRoot,
+// SetRange and TryBitAt, return ErrWrongLen unless the bitvector is exactly as long as the size
+// requires.
+type Bitvector[S Size] []byte
+
+// NewBitvector creates a new bitvector of the size given by S.
+func NewBitvector[S Size]() Bitvector[S] {
+	return make([]byte, byteSize[S]())
+}
+
+// bitSize returns the number of bits in a bitvector of size S.
+func bitSize[S Size]() uint64 {
+	var s S
+	return s.Bits()
+}
+
+// byteSize returns the number of bytes in a bitvector of size S.
+func byteSize[S Size]() int {
+	return int((bitSize[S]() + 7) >> 3)
+}
+
+// BitAt returns the bit value at the given index. If the index requested
+// exceeds the number of bits in the bitvector, then this method returns false.
+func (b Bitvector[S]) BitAt(idx uint64) bool {
+	// Out of bounds or incorrect bitvector byte size, must be false.
+	if idx >= b.Len() || len(b) != byteSize[S]() {
+		return false
+	}
+
+	i := uint8(1 << (idx % 8))
+	return b[idx/8]&i == i
+}
+
+// SetBitAt will set the bit at the given index to the given value. If the index
+// requested exceeds the number of bits in the bitvector, then this method does
+// nothing.
+func (b Bitvector[S]) SetBitAt(idx uint64, val bool) {
+	// Out of bounds, do nothing.
+	if idx >= b.Len() || len(b) != byteSize[S]() {
+		return
+	}
+
+	bit := uint8(1 << (idx % 8))
+	if val {
+		b[idx/8] |= bit
+	} else {
+		b[idx/8] &^= bit
+	}
+}
+
+// TryBitAt returns the bit value at the given index. Unlike BitAt, it returns ErrWrongLen if the
+// bitvector is not the expected length, and an *ErrIndexOutOfRange if the index is out of bounds.
+func (b Bitvector[S]) TryBitAt(idx uint64) (bool, error) {
+	if err := b.checkIndex(idx); err != nil {
+		return false, err
+	}
+	return b.BitAt(idx), nil
+}
+
+// TrySetBitAt will set the bit at the given index to the given value. Unlike SetBitAt, it returns
+// ErrWrongLen if the bitvector is not the expected length, and an *ErrIndexOutOfRange if the index
+// is out of bounds.
+func (b Bitvector[S]) TrySetBitAt(idx uint64, val bool) error {
+	if err := b.checkIndex(idx); err != nil {
+		return err
+	}
+	b.SetBitAt(idx, val)
+	return nil
+}
+
+// Validate checks that the bitvector is a canonical SSZ encoding, which requires it to be exactly
+// long enough to hold Len() bits with the padding bits past the length set to zero.
+func (b Bitvector[S]) Validate() error {
+	return validateBitvector(b, bitSize[S]())
+}
+
+// Len returns the number of bits in the bitvector.
+func (b Bitvector[S]) Len() uint64 {
+	return bitSize[S]()
+}
+
+// Count returns the number of 1s in the bitvector.
+func (b Bitvector[S]) Count() uint64 {
+	c := 0
+	for _, bt := range b.Bytes() {
+		c += bits.OnesCount8(bt)
+	}
+	return uint64(c)
+}
+
+// Bytes returns the bytes data representing the bitvector. This method bitmasks the underlying
+// data to ensure that it is an accurate representation: bytes past the size of the bitvector are
+// dropped, and padding bits are cleared.
+func (b Bitvector[S]) Bytes() []byte {
+	if len(b) == 0 {
+		return []byte{}
+	}
+	n := bitSize[S]()
+	ln := min(len(b), byteSize[S]())
+	ret := make([]byte, ln)
+	copy(ret, b[:ln])
+	if ln == byteSize[S]() {
+		restoreHighBits(ret, n, 0)
+	}
+	return ret
+}
+
+// HashTreeRoot returns the SSZ hash tree root of the bitvector. This method will return an error
+// if the bitvector is not the expected length.
+func (b Bitvector[S]) HashTreeRoot() ([32]byte, error) {
+	if len(b) != byteSize[S]() {
+		return [32]byte{}, ErrWrongLen
+	}
+	return bitvectorHashTreeRoot(b, bitSize[S]()), nil
+}
+
+// Multiproof returns a merkle multiproof that the bits at the given indices are set. The proof can
+// be checked with VerifyBitvectorMultiproof. This method will return an error if the bitvector is
+// not the expected length.
+func (b Bitvector[S]) Multiproof(bitIndices []uint64) (*Multiproof, error) {
+	if len(b) != byteSize[S]() {
+		return nil, ErrWrongLen
+	}
+	return bitvectorMultiproof(b, bitSize[S](), bitIndices)
+}
+
+// Shift bitvector by i. If i >= 0, perform left shift, otherwise right shift. A left shift moves
+// the bit at index k to index k+i, matching BitAt indexing, and bits shifted past either end of the
+// bitvector are dropped. Padding bits are cleared.
+func (b Bitvector[S]) Shift(i int) {
+	if len(b) != byteSize[S]() {
+		return
+	}
+	n := bitSize[S]()
+	shiftBits(b, n, i)
+	restoreHighBits(b, n, 0)
+}
+
+// RotateLeft rotates bitvector by i, moving the bit at index k to index (k+i) mod Len(). A
+// negative i rotates to the right.
+func (b Bitvector[S]) RotateLeft(i int) {
+	if len(b) != byteSize[S]() {
+		return
+	}
+	n := bitSize[S]()
+	rotateBits(b, n, rotation(n, i))
+}
+
+// RotateRight rotates bitvector by i, moving the bit at index k to index (k-i) mod Len(). A
+// negative i rotates to the left.
+func (b Bitvector[S]) RotateRight(i int) {
+	if len(b) != byteSize[S]() {
+		return
+	}
+	n := bitSize[S]()
+	rotateBits(b, n, n-rotation(n, i))
+}
+
+// BitIndices returns the list of indices that are set to 1.
+func (b Bitvector[S]) BitIndices() []int {
+	bs := b.Bytes()
+	indices := make([]int, 0, len(bs)*8)
+	for i, bt := range bs {
+		for j := 0; j < 8; j++ {
+			bit := byte(1 << uint(j))
+			if bt&bit == bit {
+				indices = append(indices, i*8+j)
+			}
+		}
+	}
+
+	return indices
+}
+
+// All returns an iterator over the indices which are set to 1, in ascending order. Nothing is
+// yielded if the bitvector is not the expected length.
+func (b Bitvector[S]) All() iter.Seq[uint64] {
+	if len(b) != byteSize[S]() {
+		return bitsSeq(nil, 0, false)
+	}
+	return bitsSeq(b, bitSize[S](), false)
+}
+
+// AllUnset returns an iterator over the indices which are set to 0, in ascending order. Nothing is
+// yielded if the bitvector is not the expected length.
+func (b Bitvector[S]) AllUnset() iter.Seq[uint64] {
+	if len(b) != byteSize[S]() {
+		return bitsSeq(nil, 0, true)
+	}
+	return bitsSeq(b, bitSize[S](), true)
+}
+
+// NextSet returns the lowest index at or after from of a bit set to 1. The second return value is
+// false if there is no such bit or the bitvector is not the expected length.
+func (b Bitvector[S]) NextSet(from uint64) (uint64, bool) {
+	if len(b) != byteSize[S]() {
+		return 0, false
+	}
+	return nextBit(b, bitSize[S](), from, false)
+}
+
+// NextClear returns the lowest index at or after from of a bit set to 0. The second return value
+// is false if there is no such bit or the bitvector is not the expected length.
+func (b Bitvector[S]) NextClear(from uint64) (uint64, bool) {
+	if len(b) != byteSize[S]() {
+		return 0, false
+	}
+	return nextBit(b, bitSize[S](), from, true)
+}
+
+// PrevSet returns the highest index at or before from of a bit set to 1, starting from the last
+// bit if from is out of range. The second return value is false if there is no such bit or the
+// bitvector is not the expected length.
+func (b Bitvector[S]) PrevSet(from uint64) (uint64, bool) {
+	if len(b) != byteSize[S]() {
+		return 0, false
+	}
+	return prevBit(b, bitSize[S](), from, false)
+}
+
+// PrevClear returns the highest index at or before from of a bit set to 0, starting from the last
+// bit if from is out of range. The second return value is false if there is no such bit or the
+// bitvector is not the expected length.
+func (b Bitvector[S]) PrevClear(from uint64) (uint64, bool) {
+	if len(b) != byteSize[S]() {
+		return 0, false
+	}
+	return prevBit(b, bitSize[S](), from, true)
+}
+
+// Contains returns true if the bitvector contains all of the bits from the provided argument
+// bitvector. This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) Contains(c Bitvector[S]) (bool, error) {
+	if err := b.checkLen(c); err != nil {
+		return false, err
+	}
+
+	// To ensure all of the bits in c are present in b, we iterate over every byte, combine
+	// the byte from b and c, then XOR them against b. If the result of this is non-zero, then we
+	// are assured that a byte in c had bits not present in b.
+	for i := 0; i < len(b); i++ {
+		if (b[i]^(b[i]|c[i]))&b.byteMask(i) != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Overlaps returns true if the bitvector contains one of the bits from the provided argument
+// bitvector. This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) Overlaps(c Bitvector[S]) (bool, error) {
+	if err := b.checkLen(c); err != nil {
+		return false, err
+	}
+
+	for i := 0; i < len(b); i++ {
+		if b[i]&c[i]&b.byteMask(i) != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Or returns the OR result of the two bitvectors (union). This method will return an error if
+// the bitvectors are not the same length.
+func (b Bitvector[S]) Or(c Bitvector[S]) (Bitvector[S], error) {
+	if err := b.checkLen(c); err != nil {
+		return nil, err
+	}
+
+	ret := make(Bitvector[S], len(b))
+	b.or(c, ret)
+	return ret, nil
+}
+
+// NoAllocOr computes the OR result of the two bitvectors (union).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) NoAllocOr(c, ret Bitvector[S]) error {
+	if err := b.checkLen(c, ret); err != nil {
+		return err
+	}
+
+	b.or(c, ret)
+	return nil
+}
+
+// OrCount calculates number of bits set in a union of two bitvectors.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) OrCount(c Bitvector[S]) (uint64, error) {
+	if err := b.checkLen(c); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8((b[i] | c[i]) & b.byteMask(i))
+	}
+	return uint64(cnt), nil
+}
+
+// And returns the AND result of the two bitvectors (intersection). This method will return an
+// error if the bitvectors are not the same length.
+func (b Bitvector[S]) And(c Bitvector[S]) (Bitvector[S], error) {
+	if err := b.checkLen(c); err != nil {
+		return nil, err
+	}
+
+	ret := make(Bitvector[S], len(b))
+	b.and(c, ret)
+	return ret, nil
+}
+
+// NoAllocAnd computes the AND result of the two bitvectors (intersection).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) NoAllocAnd(c, ret Bitvector[S]) error {
+	if err := b.checkLen(c, ret); err != nil {
+		return err
+	}
+
+	b.and(c, ret)
+	return nil
+}
+
+// AndCount calculates number of bits set in an intersection of two bitvectors.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) AndCount(c Bitvector[S]) (uint64, error) {
+	if err := b.checkLen(c); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8(b[i] & c[i] & b.byteMask(i))
+	}
+	return uint64(cnt), nil
+}
+
+// Xor returns the XOR result of the two bitvectors (symmetric difference). This method will
+// return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) Xor(c Bitvector[S]) (Bitvector[S], error) {
+	if err := b.checkLen(c); err != nil {
+		return nil, err
+	}
+
+	ret := make(Bitvector[S], len(b))
+	b.xor(c, ret)
+	return ret, nil
+}
+
+// NoAllocXor computes the XOR result of the two bitvectors (symmetric difference).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) NoAllocXor(c, ret Bitvector[S]) error {
+	if err := b.checkLen(c, ret); err != nil {
+		return err
+	}
+
+	b.xor(c, ret)
+	return nil
+}
+
+// XorCount calculates number of bits set in a symmetric difference of two bitvectors.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) XorCount(c Bitvector[S]) (uint64, error) {
+	if err := b.checkLen(c); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8((b[i] ^ c[i]) & b.byteMask(i))
+	}
+	return uint64(cnt), nil
+}
+
+// AndNot returns the AND NOT result of the two bitvectors (difference), i.e. the bits set in b
+// but not in c. This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) AndNot(c Bitvector[S]) (Bitvector[S], error) {
+	if err := b.checkLen(c); err != nil {
+		return nil, err
+	}
+
+	ret := make(Bitvector[S], len(b))
+	b.andNot(c, ret)
+	return ret, nil
+}
+
+// NoAllocAndNot computes the AND NOT result of the two bitvectors (difference).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) NoAllocAndNot(c, ret Bitvector[S]) error {
+	if err := b.checkLen(c, ret); err != nil {
+		return err
+	}
+
+	b.andNot(c, ret)
+	return nil
+}
+
+// AndNotCount calculates number of bits set in a difference of two bitvectors.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) AndNotCount(c Bitvector[S]) (uint64, error) {
+	if err := b.checkLen(c); err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for i := 0; i < len(b); i++ {
+		cnt += bits.OnesCount8(b[i] &^ c[i] & b.byteMask(i))
+	}
+	return uint64(cnt), nil
+}
+
+// Not returns the NOT result of the bitvector (complement). Padding bits are left cleared.
+func (b Bitvector[S]) Not() (Bitvector[S], error) {
+	ret := make(Bitvector[S], len(b))
+	b.not(ret)
+	return ret, nil
+}
+
+// NoAllocNot computes the NOT result of the bitvector (complement).
+// Result is written into provided variable, so no allocation takes place inside the function.
+// This method will return an error if the bitvectors are not the same length.
+func (b Bitvector[S]) NoAllocNot(ret Bitvector[S]) error {
+	if err := b.checkLen(ret); err != nil {
+		return err
+	}
+
+	b.not(ret)
+	return nil
+}
+
+// OrInPlace computes the OR result of the two bitvectors (union), storing it in b. It is safe for
+// b and c to share the same storage. This method will return an error if the bitvectors are not
+// the same length.
+func (b Bitvector[S]) OrInPlace(c Bitvector[S]) error {
+	return b.NoAllocOr(c, b)
+}
+
+// AndInPlace computes the AND result of the two bitvectors (intersection), storing it in b. It is
+// safe for b and c to share the same storage. This method will return an error if the bitvectors
+// are not the same length.
+func (b Bitvector[S]) AndInPlace(c Bitvector[S]) error {
+	return b.NoAllocAnd(c, b)
+}
+
+// XorInPlace computes the XOR result of the two bitvectors (symmetric difference), storing it in
+// b. It is safe for b and c to share the same storage. This method will return an error if the
+// bitvectors are not the same length.
+func (b Bitvector[S]) XorInPlace(c Bitvector[S]) error {
+	return b.NoAllocXor(c, b)
+}
+
+// AndNotInPlace computes the AND NOT result of the two bitvectors (difference), storing it in b.
+// It is safe for b and c to share the same storage. This method will return an error if the
+// bitvectors are not the same length.
+func (b Bitvector[S]) AndNotInPlace(c Bitvector[S]) error {
+	return b.NoAllocAndNot(c, b)
+}
+
+// NotInPlace computes the NOT result of the bitvector (complement), storing it in b.
+func (b Bitvector[S]) NotInPlace() error {
+	return b.NoAllocNot(b)
+}
+
+// SetRange sets all bits in the range [from, to) to the given value. This method will return an
+// error, leaving the bitvector unchanged, if the range does not lie within the bitvector.
+func (b Bitvector[S]) SetRange(from, to uint64, val bool) error {
+	if err := b.checkRange(from, to); err != nil {
+		return err
+	}
+	setRangeBits(b, from, to, val)
+	return nil
+}
+
+// ClearRange sets all bits in the range [from, to) to 0. This method will return an error, leaving
+// the bitvector unchanged, if the range does not lie within the bitvector.
+func (b Bitvector[S]) ClearRange(from, to uint64) error {
+	return b.SetRange(from, to, false)
+}
+
+// FlipRange inverts all bits in the range [from, to). This method will return an error, leaving
+// the bitvector unchanged, if the range does not lie within the bitvector.
+func (b Bitvector[S]) FlipRange(from, to uint64) error {
+	if err := b.checkRange(from, to); err != nil {
+		return err
+	}
+	flipRangeBits(b, from, to)
+	return nil
+}
+
+// CountRange returns the number of bits set to 1 in the range [from, to). This method will return
+// an error if the range does not lie within the bitvector.
+func (b Bitvector[S]) CountRange(from, to uint64) (uint64, error) {
+	if err := b.checkRange(from, to); err != nil {
+		return 0, err
+	}
+	return countRangeBits(b, from, to), nil
+}
+
+func (b Bitvector[S]) or(c, ret Bitvector[S]) {
+	for i := range b {
+		ret[i] = (b[i] | c[i]) & b.byteMask(i)
+	}
+}
+
+func (b Bitvector[S]) and(c, ret Bitvector[S]) {
+	for i := range b {
+		ret[i] = b[i] & c[i] & b.byteMask(i)
+	}
+}
+
+func (b Bitvector[S]) xor(c, ret Bitvector[S]) {
+	for i := range b {
+		ret[i] = (b[i] ^ c[i]) & b.byteMask(i)
+	}
+}
+
+func (b Bitvector[S]) andNot(c, ret Bitvector[S]) {
+	for i := range b {
+		ret[i] = b[i] &^ c[i] & b.byteMask(i)
+	}
+}
+
+func (b Bitvector[S]) not(ret Bitvector[S]) {
+	for i := range b {
+		ret[i] = ^b[i] & b.byteMask(i)
+	}
+}
+
+// checkLen returns ErrBitvectorDifferentLength if any of the provided bitvectors differs in length
+// from b.
+func (b Bitvector[S]) checkLen(others ...Bitvector[S]) error {
+	for _, c := range others {
+		if len(c) != len(b) {
+			return ErrBitvectorDifferentLength
+		}
+	}
+	return nil
+}
+
+// checkRange returns ErrWrongLen if the bitvector is not the expected length, or an error if
+// [from, to) is not a range of its bits.
+func (b Bitvector[S]) checkRange(from, to uint64) error {
+	if len(b) != byteSize[S]() {
+		return ErrWrongLen
+	}
+	return checkRange(from, to, b.Len())
+}
+
+// checkIndex returns ErrWrongLen if the bitvector is not the expected length, or an
+// *ErrIndexOutOfRange if idx is not one of its bits.
+func (b Bitvector[S]) checkIndex(idx uint64) error {
+	if len(b) != byteSize[S]() {
+		return ErrWrongLen
+	}
+	if idx >= b.Len() {
+		return &ErrIndexOutOfRange{Index: idx, Len: b.Len()}
+	}
+	return nil
+}
+
+// byteMask returns the mask of the bits in the i-th byte which are part of the bitvector, so that
+// padding bits in the last byte are ignored.
+func (b Bitvector[S]) byteMask(i int) byte {
+	n := bitSize[S]()
+	if i == byteSize[S]()-1 && n%8 != 0 {
+		return 0xff >> (8 - n%8)
+	}
+	return 0xff
+}
+
+// validateBitvector checks that b is the canonical encoding of a bitvector of n bits: it must be
+// exactly long enough to hold n bits, and any bits past n in the last byte must be zero.
+func validateBitvector(b []byte, n uint64) error {
+	if uint64(len(b)) != (n+7)>>3 {
+		return ErrWrongLen
+	}
+	if n%8 != 0 && b[len(b)-1]>>(n%8) != 0 {
+		return ErrBitvectorNonzeroPadding
+	}
+	return nil
+}
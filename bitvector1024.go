@@ -0,0 +1,23 @@
+package bitfield
+
+var _ = Bitfield(Bitvector1024{})
+
+// Size1024 is the Size of a Bitvector1024.
+type Size1024 struct{}
+
+// Bits returns 1024.
+func (Size1024) Bits() uint64 { return 1024 }
+
+// Bitvector1024 is a bitfield with a fixed defined size of 1024. There is no length bit
+// present in the underlying byte array.
+type Bitvector1024 = Bitvector[Size1024]
+
+// NewBitvector1024 creates a new bitvector of size 1024.
+func NewBitvector1024() Bitvector1024 {
+	return NewBitvector[Size1024]()
+}
+
+// ParseBitvector1024 returns the bitvector of size 1024 holding the bits of s, see ParseBitvector.
+func ParseBitvector1024(s string) (Bitvector1024, error) {
+	return ParseBitvector[Size1024](s)
+}
@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -304,44 +305,386 @@ func TestBitvector128_Shift(t *testing.T) {
 		want      Bitvector128
 	}{
 		{
-			bitvector: Bitvector128{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
-			shift:     1,
-			want:      Bitvector128{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
-		},
-		{
-			bitvector: Bitvector128{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     1,
-			want:      Bitvector128{0x02, 0x47, 0xC5, 0xFD, 0xBB, 0x59, 0x5B, 0x5A},
-		},
-		{
-			bitvector: Bitvector128{0x23, 0x01, 0xAD, 0xE2, 0xDD, 0xFE, 0xAC, 0xAD},
-			shift:     1,
-			want:      Bitvector128{0x46, 0x03, 0x5b, 0xc5, 0xBB, 0xFD, 0x59, 0x5A},
-		},
-		{
-			bitvector: Bitvector128{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     -1,
-			want:      Bitvector128{0x00, 0x91, 0xf1, 0x7f, 0x6e, 0xd6, 0x56, 0xd6},
-		},
-		{
-			bitvector: Bitvector128{0xd6, 0x23, 0x6e, 0x91, 0xDD, 0xAC, 0x7f, 0xE2},
-			shift:     -1,
-			want:      Bitvector128{0x6b, 0x11, 0xb7, 0x48, 0xee, 0xd6, 0x3f, 0xf1},
-		},
-		{
-			bitvector: Bitvector128{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     3,
-			want:      Bitvector128{0x09, 0x1f, 0x17, 0xf6, 0xed, 0x65, 0x6d, 0x68},
+			bitvector: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector128{
+				0x02, 0x46, 0xc4, 0xfd, 0xbb, 0x59, 0x5b, 0x5b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x23, 0x01, 0xad, 0xe2, 0xdd, 0xfe, 0xac, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector128{
+				0x46, 0x02, 0x5a, 0xc5, 0xbb, 0xfd, 0x59, 0x5b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -1,
+			want: Bitvector128{
+				0x80, 0x11, 0x71, 0xff, 0x6e, 0xd6, 0xd6, 0x56, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0xd6, 0x23, 0x6e, 0x91, 0xdd, 0xac, 0x7f, 0xe2, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -1,
+			want: Bitvector128{
+				0xeb, 0x11, 0xb7, 0xc8, 0x6e, 0xd6, 0x3f, 0x71, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 3,
+			want: Bitvector128{
+				0x08, 0x18, 0x11, 0xf7, 0xef, 0x66, 0x6d, 0x6d, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x17, 0xdd, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -3,
+			want: Bitvector128{
+				0xa2, 0x3b, 0xe1, 0xe2, 0xe3, 0xc2, 0xbe, 0x1d, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 8,
+			want: Bitvector128{
+				0x00, 0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: 63,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x05, 0x98, 0x2a, 0xbd, 0x4f, 0xe2, 0x74, 0x87,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: 64,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: 65,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x16, 0x60, 0xaa, 0xf4, 0x3e, 0x89, 0xd3, 0x1d,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: -70,
+			want: Bitvector128{
+				0x60, 0xf5, 0x89, 0x1e, 0xb3, 0x47, 0xd8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: 127,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: -127,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: 128,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: -128,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: 256,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+			},
+			shift: -256,
+			want: Bitvector128{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector128, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.Shift(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).Shift(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+
+	t.Run("wrong length", func(t *testing.T) {
+		bv := Bitvector128{0x01, 0x02}
+		bv.Shift(1)
+		if !bytes.Equal(bv, Bitvector128{0x01, 0x02}) {
+			t.Errorf("Shift() modified bitvector of wrong length: %x", bv)
+		}
+	})
+}
+
+func TestBitvector128_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector128
+		shift     int
+		want      Bitvector128
+	}{
 		{
-			bitvector: Bitvector128{0x17, 0xDD, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed},
-			shift:     -3,
-			want:      Bitvector128{0x02, 0xfb, 0xa1, 0x22, 0xe3, 0xe2, 0xfe, 0xdd},
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 0,
+			want: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 1,
+			want: Bitvector128{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: -1,
+			want: Bitvector128{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 3,
+			want: Bitvector128{
+				0xde, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3, 0x1c, 0x46, 0x6f, 0x90, 0xb9, 0xe2, 0x0b, 0x35,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 127,
+			want: Bitvector128{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 128,
+			want: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 129,
+			want: Bitvector128{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: -129,
+			want: Bitvector128{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 259,
+			want: Bitvector128{
+				0xde, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3, 0x1c, 0x46, 0x6f, 0x90, 0xb9, 0xe2, 0x0b, 0x35,
+			},
 		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector128, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector128_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector128
+		shift     int
+		want      Bitvector128
+	}{
 		{
-			bitvector: Bitvector128{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     8,
-			want:      Bitvector128{0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00},
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 0,
+			want: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 1,
+			want: Bitvector128{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: -1,
+			want: Bitvector128{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 3,
+			want: Bitvector128{
+				0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73, 0x18, 0xbd, 0x41, 0xe6, 0x8a, 0x2f, 0xd4, 0x78,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 127,
+			want: Bitvector128{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 128,
+			want: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 129,
+			want: Bitvector128{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: -129,
+			want: Bitvector128{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+			},
+		},
+		{
+			bitvector: Bitvector128{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+			},
+			shift: 259,
+			want: Bitvector128{
+				0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73, 0x18, 0xbd, 0x41, 0xe6, 0x8a, 0x2f, 0xd4, 0x78,
+			},
 		},
 	}
 
@@ -349,10 +692,10 @@ func TestBitvector128_Shift(t *testing.T) {
 		original := make(Bitvector128, len(tt.bitvector))
 		copy(original, tt.bitvector)
 
-		tt.bitvector.Shift(tt.shift)
+		tt.bitvector.RotateRight(tt.shift)
 		if !bytes.Equal(tt.bitvector, tt.want) {
 			t.Errorf(
-				"(%x).Shift(%d) = %x, wanted %x",
+				"(%x).RotateRight(%d) = %x, wanted %x",
 				original,
 				tt.shift,
 				tt.bitvector,
@@ -596,3 +939,90 @@ func TestBitVector128_Or(t *testing.T) {
 		}
 	}
 }
+
+func TestBitvector128_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector128
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector128(bytes.Repeat([]byte{0x00}, 16)),
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127},
+		},
+		{
+			bitvector: Bitvector128{
+				0x35, 0x6a, 0x9f, 0xd4, 0x09, 0x3e, 0x73, 0xa8, 0xdd, 0x12, 0x47, 0x7c, 0xb1, 0xe6, 0x1b, 0x50,
+			},
+			want:      []uint64{0, 2, 4, 5, 9, 11, 13, 14, 16, 17, 18, 19, 20, 23, 26, 28, 30, 31, 32, 35, 41, 42, 43, 44, 45, 48, 49, 52, 53, 54, 59, 61, 63, 64, 66, 67, 68, 70, 71, 73, 76, 80, 81, 82, 86, 90, 91, 92, 93, 94, 96, 100, 101, 103, 105, 106, 109, 110, 111, 112, 113, 115, 116, 124, 126},
+			wantUnset: []uint64{1, 3, 6, 7, 8, 10, 12, 15, 21, 22, 24, 25, 27, 29, 33, 34, 36, 37, 38, 39, 40, 46, 47, 50, 51, 55, 56, 57, 58, 60, 62, 65, 69, 72, 74, 75, 77, 78, 79, 83, 84, 85, 87, 88, 89, 95, 97, 98, 99, 102, 104, 107, 108, 114, 117, 118, 119, 120, 121, 122, 123, 125, 127},
+		},
+		{
+			bitvector: Bitvector128(bytes.Repeat([]byte{0xff}, 16)),
+			want:      []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector128(bytes.Repeat([]byte{0xff}, 17)),
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range Bitvector128(bytes.Repeat([]byte{0xff}, 16)).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector128_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector128
+		want      error
+	}{
+		{
+			bitvector: NewBitvector128(),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector128(bytes.Repeat([]byte{0xff}, 16)),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector128{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector128(make([]byte, 15)),
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector128(make([]byte, 17)),
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
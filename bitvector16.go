@@ -0,0 +1,23 @@
+package bitfield
+
+var _ = Bitfield(Bitvector16{})
+
+// Size16 is the Size of a Bitvector16.
+type Size16 struct{}
+
+// Bits returns 16.
+func (Size16) Bits() uint64 { return 16 }
+
+// Bitvector16 is a bitfield with a fixed defined size of 16. There is no length bit
+// present in the underlying byte array.
+type Bitvector16 = Bitvector[Size16]
+
+// NewBitvector16 creates a new bitvector of size 16.
+func NewBitvector16() Bitvector16 {
+	return NewBitvector[Size16]()
+}
+
+// ParseBitvector16 returns the bitvector of size 16 holding the bits of s, see ParseBitvector.
+func ParseBitvector16(s string) (Bitvector16, error) {
+	return ParseBitvector[Size16](s)
+}
@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -348,44 +349,202 @@ func TestBitvector256_Shift(t *testing.T) {
 		want      Bitvector256
 	}{
 		{
-			bitvector: Bitvector256{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
-			shift:     1,
-			want:      Bitvector256{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			bitvector: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector256{
+				0x02, 0x46, 0xc4, 0xfd, 0xbb, 0x59, 0x5b, 0x5b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x23, 0x01, 0xad, 0xe2, 0xdd, 0xfe, 0xac, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector256{
+				0x46, 0x02, 0x5a, 0xc5, 0xbb, 0xfd, 0x59, 0x5b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -1,
+			want: Bitvector256{
+				0x80, 0x11, 0x71, 0xff, 0x6e, 0xd6, 0xd6, 0x56, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0xd6, 0x23, 0x6e, 0x91, 0xdd, 0xac, 0x7f, 0xe2, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -1,
+			want: Bitvector256{
+				0xeb, 0x11, 0xb7, 0xc8, 0x6e, 0xd6, 0x3f, 0x71, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 3,
+			want: Bitvector256{
+				0x08, 0x18, 0x11, 0xf7, 0xef, 0x66, 0x6d, 0x6d, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x17, 0xdd, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -3,
+			want: Bitvector256{
+				0xa2, 0x3b, 0xe1, 0xe2, 0xe3, 0xc2, 0xbe, 0x1d, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector256{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     1,
-			want:      Bitvector256{0x02, 0x47, 0xC5, 0xFD, 0xBB, 0x59, 0x5B, 0x5A},
+			bitvector: Bitvector256{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 8,
+			want: Bitvector256{
+				0x00, 0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: 63,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x05, 0x98, 0x2a, 0xbd, 0x4f, 0xe2, 0x74, 0x87,
+				0x19, 0xac, 0x3e, 0xd1, 0x63, 0xf6, 0x08, 0x9b, 0x2d, 0xc0, 0x52, 0xe5, 0x77, 0x8a, 0x1c, 0xaf,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: 64,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e,
+				0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36, 0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: 65,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x16, 0x60, 0xaa, 0xf4, 0x3e, 0x89, 0xd3, 0x1d,
+				0x66, 0xb0, 0xfa, 0x44, 0x8f, 0xd9, 0x23, 0x6c, 0xb6, 0x00, 0x4b, 0x95, 0xdf, 0x29, 0x72, 0xbc,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: -70,
+			want: Bitvector256{
+				0x60, 0xf5, 0x89, 0x1e, 0xb3, 0x47, 0xd8, 0x6c, 0x01, 0x96, 0x2a, 0xbf, 0x53, 0xe4, 0x78, 0x0d,
+				0xa2, 0x36, 0xcb, 0x5f, 0xf0, 0x84, 0x19, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector256{0x23, 0x01, 0xAD, 0xE2, 0xDD, 0xFE, 0xAC, 0xAD},
-			shift:     1,
-			want:      Bitvector256{0x46, 0x03, 0x5b, 0xc5, 0xBB, 0xFD, 0x59, 0x5A},
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: 255,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80,
+			},
 		},
 		{
-			bitvector: Bitvector256{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     -1,
-			want:      Bitvector256{0x00, 0x91, 0xf1, 0x7f, 0x6e, 0xd6, 0x56, 0xd6},
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: -255,
+			want: Bitvector256{
+				0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector256{0xd6, 0x23, 0x6e, 0x91, 0xDD, 0xAC, 0x7f, 0xE2},
-			shift:     -1,
-			want:      Bitvector256{0x6b, 0x11, 0xb7, 0x48, 0xee, 0xd6, 0x3f, 0xf1},
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: 256,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector256{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     3,
-			want:      Bitvector256{0x09, 0x1f, 0x17, 0xf6, 0xed, 0x65, 0x6d, 0x68},
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: -256,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector256{0x17, 0xDD, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed},
-			shift:     -3,
-			want:      Bitvector256{0x02, 0xfb, 0xa1, 0x22, 0xe3, 0xe2, 0xfe, 0xdd},
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: 256,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector256{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     8,
-			want:      Bitvector256{0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00},
+			bitvector: Bitvector256{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+			shift: -256,
+			want: Bitvector256{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 	}
 
@@ -404,6 +563,262 @@ func TestBitvector256_Shift(t *testing.T) {
 			)
 		}
 	}
+
+	t.Run("wrong length", func(t *testing.T) {
+		bv := Bitvector256{0x01, 0x02}
+		bv.Shift(1)
+		if !bytes.Equal(bv, Bitvector256{0x01, 0x02}) {
+			t.Errorf("Shift() modified bitvector of wrong length: %x", bv)
+		}
+	})
+}
+
+func TestBitvector256_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector256
+		shift     int
+		want      Bitvector256
+	}{
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 0,
+			want: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 1,
+			want: Bitvector256{
+				0x36, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: -1,
+			want: Bitvector256{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 3,
+			want: Bitvector256{
+				0xd8, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3, 0x1c, 0x46, 0x6f, 0x90, 0xb9, 0xe2, 0x0b, 0x35,
+				0x5e, 0x87, 0xa8, 0xd1, 0xfa, 0x23, 0x4d, 0x76, 0x9f, 0xc0, 0xe9, 0x12, 0x3c, 0x65, 0x8e, 0xb7,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 255,
+			want: Bitvector256{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 256,
+			want: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 257,
+			want: Bitvector256{
+				0x36, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: -257,
+			want: Bitvector256{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 515,
+			want: Bitvector256{
+				0xd8, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3, 0x1c, 0x46, 0x6f, 0x90, 0xb9, 0xe2, 0x0b, 0x35,
+				0x5e, 0x87, 0xa8, 0xd1, 0xfa, 0x23, 0x4d, 0x76, 0x9f, 0xc0, 0xe9, 0x12, 0x3c, 0x65, 0x8e, 0xb7,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector256, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector256_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector256
+		shift     int
+		want      Bitvector256
+	}{
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 0,
+			want: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 1,
+			want: Bitvector256{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: -1,
+			want: Bitvector256{
+				0x36, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 3,
+			want: Bitvector256{
+				0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73, 0x18, 0xbd, 0x41, 0xe6, 0x8a, 0x2f, 0xd4, 0x78,
+				0x1d, 0xa2, 0x46, 0xeb, 0x8f, 0x34, 0xd9, 0x7d, 0x02, 0xa7, 0x4b, 0xf0, 0x94, 0x39, 0xde, 0x62,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 255,
+			want: Bitvector256{
+				0x36, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 256,
+			want: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 257,
+			want: Bitvector256{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: -257,
+			want: Bitvector256{
+				0x36, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+			},
+		},
+		{
+			bitvector: Bitvector256{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+			},
+			shift: 515,
+			want: Bitvector256{
+				0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73, 0x18, 0xbd, 0x41, 0xe6, 0x8a, 0x2f, 0xd4, 0x78,
+				0x1d, 0xa2, 0x46, 0xeb, 0x8f, 0x34, 0xd9, 0x7d, 0x02, 0xa7, 0x4b, 0xf0, 0x94, 0x39, 0xde, 0x62,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector256, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateRight(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
 }
 
 func TestBitvector256_BitIndices(t *testing.T) {
@@ -445,3 +860,91 @@ func TestBitvector256_BitIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestBitvector256_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector256
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector256(bytes.Repeat([]byte{0x00}, 32)),
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127, 128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143, 144, 145, 146, 147, 148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 159, 160, 161, 162, 163, 164, 165, 166, 167, 168, 169, 170, 171, 172, 173, 174, 175, 176, 177, 178, 179, 180, 181, 182, 183, 184, 185, 186, 187, 188, 189, 190, 191, 192, 193, 194, 195, 196, 197, 198, 199, 200, 201, 202, 203, 204, 205, 206, 207, 208, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 221, 222, 223, 224, 225, 226, 227, 228, 229, 230, 231, 232, 233, 234, 235, 236, 237, 238, 239, 240, 241, 242, 243, 244, 245, 246, 247, 248, 249, 250, 251, 252, 253, 254, 255},
+		},
+		{
+			bitvector: Bitvector256{
+				0x35, 0x6a, 0x9f, 0xd4, 0x09, 0x3e, 0x73, 0xa8, 0xdd, 0x12, 0x47, 0x7c, 0xb1, 0xe6, 0x1b, 0x50,
+				0x85, 0xba, 0xef, 0x24, 0x59, 0x8e, 0xc3, 0xf8, 0x2d, 0x62, 0x97, 0xcc, 0x01, 0x36, 0x6b, 0xa0,
+			},
+			want:      []uint64{0, 2, 4, 5, 9, 11, 13, 14, 16, 17, 18, 19, 20, 23, 26, 28, 30, 31, 32, 35, 41, 42, 43, 44, 45, 48, 49, 52, 53, 54, 59, 61, 63, 64, 66, 67, 68, 70, 71, 73, 76, 80, 81, 82, 86, 90, 91, 92, 93, 94, 96, 100, 101, 103, 105, 106, 109, 110, 111, 112, 113, 115, 116, 124, 126, 128, 130, 135, 137, 139, 140, 141, 143, 144, 145, 146, 147, 149, 150, 151, 154, 157, 160, 163, 164, 166, 169, 170, 171, 175, 176, 177, 182, 183, 187, 188, 189, 190, 191, 192, 194, 195, 197, 201, 205, 206, 208, 209, 210, 212, 215, 218, 219, 222, 223, 224, 233, 234, 236, 237, 240, 241, 243, 245, 246, 253, 255},
+			wantUnset: []uint64{1, 3, 6, 7, 8, 10, 12, 15, 21, 22, 24, 25, 27, 29, 33, 34, 36, 37, 38, 39, 40, 46, 47, 50, 51, 55, 56, 57, 58, 60, 62, 65, 69, 72, 74, 75, 77, 78, 79, 83, 84, 85, 87, 88, 89, 95, 97, 98, 99, 102, 104, 107, 108, 114, 117, 118, 119, 120, 121, 122, 123, 125, 127, 129, 131, 132, 133, 134, 136, 138, 142, 148, 152, 153, 155, 156, 158, 159, 161, 162, 165, 167, 168, 172, 173, 174, 178, 179, 180, 181, 184, 185, 186, 193, 196, 198, 199, 200, 202, 203, 204, 207, 211, 213, 214, 216, 217, 220, 221, 225, 226, 227, 228, 229, 230, 231, 232, 235, 238, 239, 242, 244, 247, 248, 249, 250, 251, 252, 254},
+		},
+		{
+			bitvector: Bitvector256(bytes.Repeat([]byte{0xff}, 32)),
+			want:      []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127, 128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143, 144, 145, 146, 147, 148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 159, 160, 161, 162, 163, 164, 165, 166, 167, 168, 169, 170, 171, 172, 173, 174, 175, 176, 177, 178, 179, 180, 181, 182, 183, 184, 185, 186, 187, 188, 189, 190, 191, 192, 193, 194, 195, 196, 197, 198, 199, 200, 201, 202, 203, 204, 205, 206, 207, 208, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 221, 222, 223, 224, 225, 226, 227, 228, 229, 230, 231, 232, 233, 234, 235, 236, 237, 238, 239, 240, 241, 242, 243, 244, 245, 246, 247, 248, 249, 250, 251, 252, 253, 254, 255},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector256(bytes.Repeat([]byte{0xff}, 33)),
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range Bitvector256(bytes.Repeat([]byte{0xff}, 32)).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector256_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector256
+		want      error
+	}{
+		{
+			bitvector: NewBitvector256(),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector256(bytes.Repeat([]byte{0xff}, 32)),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector256{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector256(make([]byte, 31)),
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector256(make([]byte, 33)),
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
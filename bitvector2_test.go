@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -327,6 +328,176 @@ func TestBitvector2_Shift(t *testing.T) {
 	}
 }
 
+func TestBitvector2_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector2
+		shift     int
+		want      Bitvector2
+	}{
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     0,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     1,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     -1,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     2,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     3,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     -3,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     0,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     1,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     -1,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     2,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     3,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     -3,
+			want:      Bitvector2{0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector2, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector2_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector2
+		shift     int
+		want      Bitvector2
+	}{
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     0,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     1,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     -1,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     2,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     3,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			shift:     -3,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     0,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     1,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     -1,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     2,
+			want:      Bitvector2{0x02},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     3,
+			want:      Bitvector2{0x01},
+		},
+		{
+			bitvector: Bitvector2{0x02},
+			shift:     -3,
+			want:      Bitvector2{0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector2, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateRight(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
 func TestBitvector2_BitIndices(t *testing.T) {
 	tests := []struct {
 		a    Bitvector2
@@ -360,4 +531,98 @@ func TestBitvector2_BitIndices(t *testing.T) {
 			)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestBitvector2_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector2
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector2{0x00},
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1},
+		},
+		{
+			bitvector: Bitvector2{0x01},
+			want:      []uint64{0},
+			wantUnset: []uint64{1},
+		},
+		{
+			bitvector: Bitvector2{0x03},
+			want:      []uint64{0, 1},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector2{0xff},
+			want:      []uint64{0, 1},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector2{0xff, 0xff},
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range (Bitvector2{0x03}).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector2_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector2
+		want      error
+	}{
+		{
+			bitvector: Bitvector2{0x03},
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector2{0x00},
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector2{0x04},
+			want:      ErrBitvectorNonzeroPadding,
+		},
+		{
+			bitvector: Bitvector2{0x80},
+			want:      ErrBitvectorNonzeroPadding,
+		},
+		{
+			bitvector: Bitvector2{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector2{0x00, 0x00},
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -269,6 +270,216 @@ func TestBitvector32_Bytes(t *testing.T) {
 	}
 }
 
+func TestBitvector32_Shift(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector32
+		shift     int
+		want      Bitvector32
+	}{
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     0,
+			want:      Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     1,
+			want:      Bitvector32{0x36, 0x81, 0xcb, 0x15},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     3,
+			want:      Bitvector32{0xd8, 0x04, 0x2e, 0x57},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -1,
+			want:      Bitvector32{0x4d, 0xe0, 0x72, 0x05},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -5,
+			want:      Bitvector32{0x04, 0x2e, 0x57, 0x00},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     31,
+			want:      Bitvector32{0x00, 0x00, 0x00, 0x80},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -31,
+			want:      Bitvector32{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     32,
+			want:      Bitvector32{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -32,
+			want:      Bitvector32{0x00, 0x00, 0x00, 0x00},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector32, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.Shift(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).Shift(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector32_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector32
+		shift     int
+		want      Bitvector32
+	}{
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     0,
+			want:      Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     1,
+			want:      Bitvector32{0x36, 0x81, 0xcb, 0x15},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -1,
+			want:      Bitvector32{0x4d, 0xe0, 0x72, 0x85},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     3,
+			want:      Bitvector32{0xd8, 0x04, 0x2e, 0x57},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     31,
+			want:      Bitvector32{0x4d, 0xe0, 0x72, 0x85},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     32,
+			want:      Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     33,
+			want:      Bitvector32{0x36, 0x81, 0xcb, 0x15},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -33,
+			want:      Bitvector32{0x4d, 0xe0, 0x72, 0x85},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     67,
+			want:      Bitvector32{0xd8, 0x04, 0x2e, 0x57},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector32, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector32_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector32
+		shift     int
+		want      Bitvector32
+	}{
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     0,
+			want:      Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     1,
+			want:      Bitvector32{0x4d, 0xe0, 0x72, 0x85},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -1,
+			want:      Bitvector32{0x36, 0x81, 0xcb, 0x15},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     3,
+			want:      Bitvector32{0x13, 0xb8, 0x5c, 0x61},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     31,
+			want:      Bitvector32{0x36, 0x81, 0xcb, 0x15},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     32,
+			want:      Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     33,
+			want:      Bitvector32{0x4d, 0xe0, 0x72, 0x85},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     -33,
+			want:      Bitvector32{0x36, 0x81, 0xcb, 0x15},
+		},
+		{
+			bitvector: Bitvector32{0x9b, 0xc0, 0xe5, 0x0a},
+			shift:     67,
+			want:      Bitvector32{0x13, 0xb8, 0x5c, 0x61},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector32, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateRight(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
 func TestBitVector32_BitIndices(t *testing.T) {
 	tests := []struct {
 		a    Bitvector32
@@ -311,3 +522,88 @@ func TestBitVector32_BitIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestBitvector32_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector32
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector32{0x00, 0x00, 0x00, 0x00},
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+		},
+		{
+			bitvector: Bitvector32{0x35, 0x6a, 0x9f, 0xd4},
+			want:      []uint64{0, 2, 4, 5, 9, 11, 13, 14, 16, 17, 18, 19, 20, 23, 26, 28, 30, 31},
+			wantUnset: []uint64{1, 3, 6, 7, 8, 10, 12, 15, 21, 22, 24, 25, 27, 29},
+		},
+		{
+			bitvector: Bitvector32{0xff, 0xff, 0xff, 0xff},
+			want:      []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector32{0xff, 0xff, 0xff, 0xff, 0xff},
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range Bitvector32(bytes.Repeat([]byte{0xff}, 4)).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector32_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector32
+		want      error
+	}{
+		{
+			bitvector: NewBitvector32(),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector32{0xff, 0xff, 0xff, 0xff},
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector32{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector32{0x00, 0x00, 0x00},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector32{0x00, 0x00, 0x00, 0x00, 0x00},
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
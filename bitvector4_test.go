@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -361,6 +362,126 @@ func TestBitvector4_Shift(t *testing.T) {
 	}
 }
 
+func TestBitvector4_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector4
+		shift     int
+		want      Bitvector4
+	}{
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     0,
+			want:      Bitvector4{0x0b},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     1,
+			want:      Bitvector4{0x07},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     -1,
+			want:      Bitvector4{0x0d},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     3,
+			want:      Bitvector4{0x0d},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     4,
+			want:      Bitvector4{0x0b},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     5,
+			want:      Bitvector4{0x07},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     -5,
+			want:      Bitvector4{0x0d},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector4, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector4_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector4
+		shift     int
+		want      Bitvector4
+	}{
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     0,
+			want:      Bitvector4{0x0b},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     1,
+			want:      Bitvector4{0x0d},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     -1,
+			want:      Bitvector4{0x07},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     3,
+			want:      Bitvector4{0x07},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     4,
+			want:      Bitvector4{0x0b},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     5,
+			want:      Bitvector4{0x0d},
+		},
+		{
+			bitvector: Bitvector4{0x0b},
+			shift:     -5,
+			want:      Bitvector4{0x07},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector4, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateRight(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
 func TestBitvector4_BitIndices(t *testing.T) {
 	tests := []struct {
 		a    Bitvector4
@@ -395,3 +516,97 @@ func TestBitvector4_BitIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestBitvector4_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector4
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector4{0x00},
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3},
+		},
+		{
+			bitvector: Bitvector4{0x0a},
+			want:      []uint64{1, 3},
+			wantUnset: []uint64{0, 2},
+		},
+		{
+			bitvector: Bitvector4{0x0f},
+			want:      []uint64{0, 1, 2, 3},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector4{0xff},
+			want:      []uint64{0, 1, 2, 3},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector4{0xff, 0xff},
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range (Bitvector4{0x0f}).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector4_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector4
+		want      error
+	}{
+		{
+			bitvector: Bitvector4{0x0f},
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector4{0x00},
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector4{0x10},
+			want:      ErrBitvectorNonzeroPadding,
+		},
+		{
+			bitvector: Bitvector4{0x80},
+			want:      ErrBitvectorNonzeroPadding,
+		},
+		{
+			bitvector: Bitvector4{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector4{0x00, 0x00},
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
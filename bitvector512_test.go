@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -423,44 +424,274 @@ func TestBitvector512_Shift(t *testing.T) {
 		want      Bitvector512
 	}{
 		{
-			bitvector: Bitvector512{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
-			shift:     1,
-			want:      Bitvector512{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			bitvector: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector512{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     1,
-			want:      Bitvector512{0x02, 0x47, 0xC5, 0xFD, 0xBB, 0x59, 0x5B, 0x5A},
+			bitvector: Bitvector512{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector512{
+				0x02, 0x46, 0xc4, 0xfd, 0xbb, 0x59, 0x5b, 0x5b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector512{0x23, 0x01, 0xAD, 0xE2, 0xDD, 0xFE, 0xAC, 0xAD},
-			shift:     1,
-			want:      Bitvector512{0x46, 0x03, 0x5b, 0xc5, 0xBB, 0xFD, 0x59, 0x5A},
+			bitvector: Bitvector512{
+				0x23, 0x01, 0xad, 0xe2, 0xdd, 0xfe, 0xac, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 1,
+			want: Bitvector512{
+				0x46, 0x02, 0x5a, 0xc5, 0xbb, 0xfd, 0x59, 0x5b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector512{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     -1,
-			want:      Bitvector512{0x00, 0x91, 0xf1, 0x7f, 0x6e, 0xd6, 0x56, 0xd6},
+			bitvector: Bitvector512{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -1,
+			want: Bitvector512{
+				0x80, 0x11, 0x71, 0xff, 0x6e, 0xd6, 0xd6, 0x56, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector512{0xd6, 0x23, 0x6e, 0x91, 0xDD, 0xAC, 0x7f, 0xE2},
-			shift:     -1,
-			want:      Bitvector512{0x6b, 0x11, 0xb7, 0x48, 0xee, 0xd6, 0x3f, 0xf1},
+			bitvector: Bitvector512{
+				0xd6, 0x23, 0x6e, 0x91, 0xdd, 0xac, 0x7f, 0xe2, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -1,
+			want: Bitvector512{
+				0xeb, 0x11, 0xb7, 0xc8, 0x6e, 0xd6, 0x3f, 0x71, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 3,
+			want: Bitvector512{
+				0x08, 0x18, 0x11, 0xf7, 0xef, 0x66, 0x6d, 0x6d, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector512{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     3,
-			want:      Bitvector512{0x09, 0x1f, 0x17, 0xf6, 0xed, 0x65, 0x6d, 0x68},
+			bitvector: Bitvector512{
+				0x17, 0xdd, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: -3,
+			want: Bitvector512{
+				0xa2, 0x3b, 0xe1, 0xe2, 0xe3, 0xc2, 0xbe, 0x1d, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector512{0x17, 0xDD, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed},
-			shift:     -3,
-			want:      Bitvector512{0x02, 0xfb, 0xa1, 0x22, 0xe3, 0xe2, 0xfe, 0xdd},
+			bitvector: Bitvector512{
+				0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			shift: 8,
+			want: Bitvector512{
+				0x00, 0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 		{
-			bitvector: Bitvector512{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
-			shift:     8,
-			want:      Bitvector512{0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00},
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: 63,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x05, 0x98, 0x2a, 0xbd, 0x4f, 0xe2, 0x74, 0x87,
+				0x19, 0xac, 0x3e, 0xd1, 0x63, 0xf6, 0x08, 0x9b, 0x2d, 0xc0, 0x52, 0xe5, 0x77, 0x8a, 0x1c, 0xaf,
+				0x41, 0xd4, 0x66, 0xf9, 0x0b, 0x9e, 0x30, 0xc3, 0x55, 0xe8, 0x7a, 0x8d, 0x1f, 0xb2, 0x44, 0xd7,
+				0x69, 0xfc, 0x0e, 0xa1, 0x33, 0xc6, 0x58, 0xeb, 0x7d, 0x90, 0x22, 0xb5, 0x47, 0xda, 0x6c, 0xff,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: 64,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e,
+				0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36, 0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e,
+				0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86, 0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae,
+				0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6, 0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: 65,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x16, 0x60, 0xaa, 0xf4, 0x3e, 0x89, 0xd3, 0x1d,
+				0x66, 0xb0, 0xfa, 0x44, 0x8f, 0xd9, 0x23, 0x6c, 0xb6, 0x00, 0x4b, 0x95, 0xdf, 0x29, 0x72, 0xbc,
+				0x06, 0x51, 0x9b, 0xe5, 0x2f, 0x78, 0xc2, 0x0c, 0x57, 0xa1, 0xeb, 0x35, 0x7e, 0xc8, 0x12, 0x5d,
+				0xa7, 0xf1, 0x3b, 0x84, 0xce, 0x18, 0x63, 0xad, 0xf7, 0x41, 0x8a, 0xd4, 0x1e, 0x69, 0xb3, 0xfd,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: -70,
+			want: Bitvector512{
+				0x60, 0xf5, 0x89, 0x1e, 0xb3, 0x47, 0xd8, 0x6c, 0x01, 0x96, 0x2a, 0xbf, 0x53, 0xe4, 0x78, 0x0d,
+				0xa2, 0x36, 0xcb, 0x5f, 0xf0, 0x84, 0x19, 0xae, 0x42, 0xd7, 0x6b, 0xfc, 0x90, 0x25, 0xba, 0x4e,
+				0xe3, 0x77, 0x08, 0x9d, 0x31, 0xc6, 0x5a, 0xef, 0x83, 0x14, 0xa9, 0x3d, 0xd2, 0x66, 0xfb, 0x8f,
+				0x20, 0xb5, 0x49, 0xde, 0x72, 0x07, 0x98, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: 511,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: -511,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: 512,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: -512,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: 256,
+			want: Bitvector512{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e, 0x33, 0x58, 0x7d, 0xa2, 0xc7, 0xec, 0x11, 0x36,
+				0x5b, 0x80, 0xa5, 0xca, 0xef, 0x14, 0x39, 0x5e, 0x83, 0xa8, 0xcd, 0xf2, 0x17, 0x3c, 0x61, 0x86,
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+			},
+			shift: -256,
+			want: Bitvector512{
+				0xab, 0xd0, 0xf5, 0x1a, 0x3f, 0x64, 0x89, 0xae, 0xd3, 0xf8, 0x1d, 0x42, 0x67, 0x8c, 0xb1, 0xd6,
+				0xfb, 0x20, 0x45, 0x6a, 0x8f, 0xb4, 0xd9, 0xfe, 0x23, 0x48, 0x6d, 0x92, 0xb7, 0xdc, 0x01, 0x26,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
 		},
 	}
 
@@ -479,6 +710,334 @@ func TestBitvector512_Shift(t *testing.T) {
 			)
 		}
 	}
+
+	t.Run("wrong length", func(t *testing.T) {
+		bv := Bitvector512{0x01, 0x02}
+		bv.Shift(1)
+		if !bytes.Equal(bv, Bitvector512{0x01, 0x02}) {
+			t.Errorf("Shift() modified bitvector of wrong length: %x", bv)
+		}
+	})
+}
+
+func TestBitvector512_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector512
+		shift     int
+		want      Bitvector512
+	}{
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 0,
+			want: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 1,
+			want: Bitvector512{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+				0x76, 0xc0, 0x0a, 0x55, 0x9f, 0xe9, 0x33, 0x7c, 0xc6, 0x10, 0x5b, 0xa5, 0xef, 0x39, 0x82, 0xcc,
+				0x16, 0x61, 0xab, 0xf5, 0x3f, 0x88, 0xd2, 0x1c, 0x67, 0xb1, 0xfb, 0x45, 0x8e, 0xd8, 0x22, 0x6d,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: -1,
+			want: Bitvector512{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+				0x1d, 0xb0, 0x42, 0xd5, 0x67, 0xfa, 0x0c, 0x9f, 0x31, 0xc4, 0x56, 0xe9, 0x7b, 0x8e, 0x20, 0xb3,
+				0x45, 0xd8, 0x6a, 0xfd, 0x0f, 0xa2, 0x34, 0xc7, 0x59, 0xec, 0x7e, 0x91, 0x23, 0xb6, 0x48, 0xdb,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 3,
+			want: Bitvector512{
+				0xdd, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3, 0x1c, 0x46, 0x6f, 0x90, 0xb9, 0xe2, 0x0b, 0x35,
+				0x5e, 0x87, 0xa8, 0xd1, 0xfa, 0x23, 0x4d, 0x76, 0x9f, 0xc0, 0xe9, 0x12, 0x3c, 0x65, 0x8e, 0xb7,
+				0xd8, 0x01, 0x2b, 0x54, 0x7d, 0xa6, 0xcf, 0xf0, 0x19, 0x43, 0x6c, 0x95, 0xbe, 0xe7, 0x08, 0x32,
+				0x5b, 0x84, 0xad, 0xd6, 0xff, 0x20, 0x4a, 0x73, 0x9c, 0xc5, 0xee, 0x17, 0x39, 0x62, 0x8b, 0xb4,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 511,
+			want: Bitvector512{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+				0x1d, 0xb0, 0x42, 0xd5, 0x67, 0xfa, 0x0c, 0x9f, 0x31, 0xc4, 0x56, 0xe9, 0x7b, 0x8e, 0x20, 0xb3,
+				0x45, 0xd8, 0x6a, 0xfd, 0x0f, 0xa2, 0x34, 0xc7, 0x59, 0xec, 0x7e, 0x91, 0x23, 0xb6, 0x48, 0xdb,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 512,
+			want: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 513,
+			want: Bitvector512{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+				0x76, 0xc0, 0x0a, 0x55, 0x9f, 0xe9, 0x33, 0x7c, 0xc6, 0x10, 0x5b, 0xa5, 0xef, 0x39, 0x82, 0xcc,
+				0x16, 0x61, 0xab, 0xf5, 0x3f, 0x88, 0xd2, 0x1c, 0x67, 0xb1, 0xfb, 0x45, 0x8e, 0xd8, 0x22, 0x6d,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: -513,
+			want: Bitvector512{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+				0x1d, 0xb0, 0x42, 0xd5, 0x67, 0xfa, 0x0c, 0x9f, 0x31, 0xc4, 0x56, 0xe9, 0x7b, 0x8e, 0x20, 0xb3,
+				0x45, 0xd8, 0x6a, 0xfd, 0x0f, 0xa2, 0x34, 0xc7, 0x59, 0xec, 0x7e, 0x91, 0x23, 0xb6, 0x48, 0xdb,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 1027,
+			want: Bitvector512{
+				0xdd, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3, 0x1c, 0x46, 0x6f, 0x90, 0xb9, 0xe2, 0x0b, 0x35,
+				0x5e, 0x87, 0xa8, 0xd1, 0xfa, 0x23, 0x4d, 0x76, 0x9f, 0xc0, 0xe9, 0x12, 0x3c, 0x65, 0x8e, 0xb7,
+				0xd8, 0x01, 0x2b, 0x54, 0x7d, 0xa6, 0xcf, 0xf0, 0x19, 0x43, 0x6c, 0x95, 0xbe, 0xe7, 0x08, 0x32,
+				0x5b, 0x84, 0xad, 0xd6, 0xff, 0x20, 0x4a, 0x73, 0x9c, 0xc5, 0xee, 0x17, 0x39, 0x62, 0x8b, 0xb4,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector512, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector512_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector512
+		shift     int
+		want      Bitvector512
+	}{
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 0,
+			want: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 1,
+			want: Bitvector512{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+				0x1d, 0xb0, 0x42, 0xd5, 0x67, 0xfa, 0x0c, 0x9f, 0x31, 0xc4, 0x56, 0xe9, 0x7b, 0x8e, 0x20, 0xb3,
+				0x45, 0xd8, 0x6a, 0xfd, 0x0f, 0xa2, 0x34, 0xc7, 0x59, 0xec, 0x7e, 0x91, 0x23, 0xb6, 0x48, 0xdb,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: -1,
+			want: Bitvector512{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+				0x76, 0xc0, 0x0a, 0x55, 0x9f, 0xe9, 0x33, 0x7c, 0xc6, 0x10, 0x5b, 0xa5, 0xef, 0x39, 0x82, 0xcc,
+				0x16, 0x61, 0xab, 0xf5, 0x3f, 0x88, 0xd2, 0x1c, 0x67, 0xb1, 0xfb, 0x45, 0x8e, 0xd8, 0x22, 0x6d,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 3,
+			want: Bitvector512{
+				0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73, 0x18, 0xbd, 0x41, 0xe6, 0x8a, 0x2f, 0xd4, 0x78,
+				0x1d, 0xa2, 0x46, 0xeb, 0x8f, 0x34, 0xd9, 0x7d, 0x02, 0xa7, 0x4b, 0xf0, 0x94, 0x39, 0xde, 0x62,
+				0x07, 0xac, 0x50, 0xf5, 0x99, 0x3e, 0xc3, 0x67, 0x0c, 0xb1, 0x55, 0xfa, 0x9e, 0x23, 0xc8, 0x6c,
+				0x11, 0xb6, 0x5a, 0xff, 0x83, 0x28, 0xcd, 0x71, 0x16, 0xbb, 0x5f, 0xe4, 0x88, 0x2d, 0xd2, 0x76,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 511,
+			want: Bitvector512{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+				0x76, 0xc0, 0x0a, 0x55, 0x9f, 0xe9, 0x33, 0x7c, 0xc6, 0x10, 0x5b, 0xa5, 0xef, 0x39, 0x82, 0xcc,
+				0x16, 0x61, 0xab, 0xf5, 0x3f, 0x88, 0xd2, 0x1c, 0x67, 0xb1, 0xfb, 0x45, 0x8e, 0xd8, 0x22, 0x6d,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 512,
+			want: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 513,
+			want: Bitvector512{
+				0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf, 0x61, 0xf4, 0x06, 0x99, 0x2b, 0xbe, 0x50, 0xe3,
+				0x75, 0x88, 0x1a, 0xad, 0x3f, 0xd2, 0x64, 0xf7, 0x09, 0x9c, 0x2e, 0xc1, 0x53, 0xe6, 0x78, 0x8b,
+				0x1d, 0xb0, 0x42, 0xd5, 0x67, 0xfa, 0x0c, 0x9f, 0x31, 0xc4, 0x56, 0xe9, 0x7b, 0x8e, 0x20, 0xb3,
+				0x45, 0xd8, 0x6a, 0xfd, 0x0f, 0xa2, 0x34, 0xc7, 0x59, 0xec, 0x7e, 0x91, 0x23, 0xb6, 0x48, 0xdb,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: -513,
+			want: Bitvector512{
+				0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c, 0x87, 0xd1, 0x1b, 0x64, 0xae, 0xf8, 0x42, 0x8d,
+				0xd7, 0x21, 0x6a, 0xb4, 0xfe, 0x48, 0x93, 0xdd, 0x27, 0x70, 0xba, 0x04, 0x4f, 0x99, 0xe3, 0x2d,
+				0x76, 0xc0, 0x0a, 0x55, 0x9f, 0xe9, 0x33, 0x7c, 0xc6, 0x10, 0x5b, 0xa5, 0xef, 0x39, 0x82, 0xcc,
+				0x16, 0x61, 0xab, 0xf5, 0x3f, 0x88, 0xd2, 0x1c, 0x67, 0xb1, 0xfb, 0x45, 0x8e, 0xd8, 0x22, 0x6d,
+			},
+		},
+		{
+			bitvector: Bitvector512{
+				0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e, 0xc3, 0xe8, 0x0d, 0x32, 0x57, 0x7c, 0xa1, 0xc6,
+				0xeb, 0x10, 0x35, 0x5a, 0x7f, 0xa4, 0xc9, 0xee, 0x13, 0x38, 0x5d, 0x82, 0xa7, 0xcc, 0xf1, 0x16,
+				0x3b, 0x60, 0x85, 0xaa, 0xcf, 0xf4, 0x19, 0x3e, 0x63, 0x88, 0xad, 0xd2, 0xf7, 0x1c, 0x41, 0x66,
+				0x8b, 0xb0, 0xd5, 0xfa, 0x1f, 0x44, 0x69, 0x8e, 0xb3, 0xd8, 0xfd, 0x22, 0x47, 0x6c, 0x91, 0xb6,
+			},
+			shift: 1027,
+			want: Bitvector512{
+				0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73, 0x18, 0xbd, 0x41, 0xe6, 0x8a, 0x2f, 0xd4, 0x78,
+				0x1d, 0xa2, 0x46, 0xeb, 0x8f, 0x34, 0xd9, 0x7d, 0x02, 0xa7, 0x4b, 0xf0, 0x94, 0x39, 0xde, 0x62,
+				0x07, 0xac, 0x50, 0xf5, 0x99, 0x3e, 0xc3, 0x67, 0x0c, 0xb1, 0x55, 0xfa, 0x9e, 0x23, 0xc8, 0x6c,
+				0x11, 0xb6, 0x5a, 0xff, 0x83, 0x28, 0xcd, 0x71, 0x16, 0xbb, 0x5f, 0xe4, 0x88, 0x2d, 0xd2, 0x76,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector512, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateRight(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
 }
 
 func TestBitvector512_BitIndices(t *testing.T) {
@@ -529,3 +1088,93 @@ func TestBitvector512_BitIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestBitvector512_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector512
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector512(bytes.Repeat([]byte{0x00}, 64)),
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127, 128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143, 144, 145, 146, 147, 148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 159, 160, 161, 162, 163, 164, 165, 166, 167, 168, 169, 170, 171, 172, 173, 174, 175, 176, 177, 178, 179, 180, 181, 182, 183, 184, 185, 186, 187, 188, 189, 190, 191, 192, 193, 194, 195, 196, 197, 198, 199, 200, 201, 202, 203, 204, 205, 206, 207, 208, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 221, 222, 223, 224, 225, 226, 227, 228, 229, 230, 231, 232, 233, 234, 235, 236, 237, 238, 239, 240, 241, 242, 243, 244, 245, 246, 247, 248, 249, 250, 251, 252, 253, 254, 255, 256, 257, 258, 259, 260, 261, 262, 263, 264, 265, 266, 267, 268, 269, 270, 271, 272, 273, 274, 275, 276, 277, 278, 279, 280, 281, 282, 283, 284, 285, 286, 287, 288, 289, 290, 291, 292, 293, 294, 295, 296, 297, 298, 299, 300, 301, 302, 303, 304, 305, 306, 307, 308, 309, 310, 311, 312, 313, 314, 315, 316, 317, 318, 319, 320, 321, 322, 323, 324, 325, 326, 327, 328, 329, 330, 331, 332, 333, 334, 335, 336, 337, 338, 339, 340, 341, 342, 343, 344, 345, 346, 347, 348, 349, 350, 351, 352, 353, 354, 355, 356, 357, 358, 359, 360, 361, 362, 363, 364, 365, 366, 367, 368, 369, 370, 371, 372, 373, 374, 375, 376, 377, 378, 379, 380, 381, 382, 383, 384, 385, 386, 387, 388, 389, 390, 391, 392, 393, 394, 395, 396, 397, 398, 399, 400, 401, 402, 403, 404, 405, 406, 407, 408, 409, 410, 411, 412, 413, 414, 415, 416, 417, 418, 419, 420, 421, 422, 423, 424, 425, 426, 427, 428, 429, 430, 431, 432, 433, 434, 435, 436, 437, 438, 439, 440, 441, 442, 443, 444, 445, 446, 447, 448, 449, 450, 451, 452, 453, 454, 455, 456, 457, 458, 459, 460, 461, 462, 463, 464, 465, 466, 467, 468, 469, 470, 471, 472, 473, 474, 475, 476, 477, 478, 479, 480, 481, 482, 483, 484, 485, 486, 487, 488, 489, 490, 491, 492, 493, 494, 495, 496, 497, 498, 499, 500, 501, 502, 503, 504, 505, 506, 507, 508, 509, 510, 511},
+		},
+		{
+			bitvector: Bitvector512{
+				0x35, 0x6a, 0x9f, 0xd4, 0x09, 0x3e, 0x73, 0xa8, 0xdd, 0x12, 0x47, 0x7c, 0xb1, 0xe6, 0x1b, 0x50,
+				0x85, 0xba, 0xef, 0x24, 0x59, 0x8e, 0xc3, 0xf8, 0x2d, 0x62, 0x97, 0xcc, 0x01, 0x36, 0x6b, 0xa0,
+				0xd5, 0x0a, 0x3f, 0x74, 0xa9, 0xde, 0x13, 0x48, 0x7d, 0xb2, 0xe7, 0x1c, 0x51, 0x86, 0xbb, 0xf0,
+				0x25, 0x5a, 0x8f, 0xc4, 0xf9, 0x2e, 0x63, 0x98, 0xcd, 0x02, 0x37, 0x6c, 0xa1, 0xd6, 0x0b, 0x40,
+			},
+			want:      []uint64{0, 2, 4, 5, 9, 11, 13, 14, 16, 17, 18, 19, 20, 23, 26, 28, 30, 31, 32, 35, 41, 42, 43, 44, 45, 48, 49, 52, 53, 54, 59, 61, 63, 64, 66, 67, 68, 70, 71, 73, 76, 80, 81, 82, 86, 90, 91, 92, 93, 94, 96, 100, 101, 103, 105, 106, 109, 110, 111, 112, 113, 115, 116, 124, 126, 128, 130, 135, 137, 139, 140, 141, 143, 144, 145, 146, 147, 149, 150, 151, 154, 157, 160, 163, 164, 166, 169, 170, 171, 175, 176, 177, 182, 183, 187, 188, 189, 190, 191, 192, 194, 195, 197, 201, 205, 206, 208, 209, 210, 212, 215, 218, 219, 222, 223, 224, 233, 234, 236, 237, 240, 241, 243, 245, 246, 253, 255, 256, 258, 260, 262, 263, 265, 267, 272, 273, 274, 275, 276, 277, 282, 284, 285, 286, 288, 291, 293, 295, 297, 298, 299, 300, 302, 303, 304, 305, 308, 315, 318, 320, 322, 323, 324, 325, 326, 329, 332, 333, 335, 336, 337, 338, 341, 342, 343, 346, 347, 348, 352, 356, 358, 361, 362, 367, 368, 369, 371, 372, 373, 375, 380, 381, 382, 383, 384, 386, 389, 393, 395, 396, 398, 400, 401, 402, 403, 407, 410, 414, 415, 416, 419, 420, 421, 422, 423, 425, 426, 427, 429, 432, 433, 437, 438, 443, 444, 447, 448, 450, 451, 454, 455, 457, 464, 465, 466, 468, 469, 474, 475, 477, 478, 480, 485, 487, 489, 490, 492, 494, 495, 496, 497, 499, 510},
+			wantUnset: []uint64{1, 3, 6, 7, 8, 10, 12, 15, 21, 22, 24, 25, 27, 29, 33, 34, 36, 37, 38, 39, 40, 46, 47, 50, 51, 55, 56, 57, 58, 60, 62, 65, 69, 72, 74, 75, 77, 78, 79, 83, 84, 85, 87, 88, 89, 95, 97, 98, 99, 102, 104, 107, 108, 114, 117, 118, 119, 120, 121, 122, 123, 125, 127, 129, 131, 132, 133, 134, 136, 138, 142, 148, 152, 153, 155, 156, 158, 159, 161, 162, 165, 167, 168, 172, 173, 174, 178, 179, 180, 181, 184, 185, 186, 193, 196, 198, 199, 200, 202, 203, 204, 207, 211, 213, 214, 216, 217, 220, 221, 225, 226, 227, 228, 229, 230, 231, 232, 235, 238, 239, 242, 244, 247, 248, 249, 250, 251, 252, 254, 257, 259, 261, 264, 266, 268, 269, 270, 271, 278, 279, 280, 281, 283, 287, 289, 290, 292, 294, 296, 301, 306, 307, 309, 310, 311, 312, 313, 314, 316, 317, 319, 321, 327, 328, 330, 331, 334, 339, 340, 344, 345, 349, 350, 351, 353, 354, 355, 357, 359, 360, 363, 364, 365, 366, 370, 374, 376, 377, 378, 379, 385, 387, 388, 390, 391, 392, 394, 397, 399, 404, 405, 406, 408, 409, 411, 412, 413, 417, 418, 424, 428, 430, 431, 434, 435, 436, 439, 440, 441, 442, 445, 446, 449, 452, 453, 456, 458, 459, 460, 461, 462, 463, 467, 470, 471, 472, 473, 476, 479, 481, 482, 483, 484, 486, 488, 491, 493, 498, 500, 501, 502, 503, 504, 505, 506, 507, 508, 509, 511},
+		},
+		{
+			bitvector: Bitvector512(bytes.Repeat([]byte{0xff}, 64)),
+			want:      []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127, 128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143, 144, 145, 146, 147, 148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 159, 160, 161, 162, 163, 164, 165, 166, 167, 168, 169, 170, 171, 172, 173, 174, 175, 176, 177, 178, 179, 180, 181, 182, 183, 184, 185, 186, 187, 188, 189, 190, 191, 192, 193, 194, 195, 196, 197, 198, 199, 200, 201, 202, 203, 204, 205, 206, 207, 208, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 221, 222, 223, 224, 225, 226, 227, 228, 229, 230, 231, 232, 233, 234, 235, 236, 237, 238, 239, 240, 241, 242, 243, 244, 245, 246, 247, 248, 249, 250, 251, 252, 253, 254, 255, 256, 257, 258, 259, 260, 261, 262, 263, 264, 265, 266, 267, 268, 269, 270, 271, 272, 273, 274, 275, 276, 277, 278, 279, 280, 281, 282, 283, 284, 285, 286, 287, 288, 289, 290, 291, 292, 293, 294, 295, 296, 297, 298, 299, 300, 301, 302, 303, 304, 305, 306, 307, 308, 309, 310, 311, 312, 313, 314, 315, 316, 317, 318, 319, 320, 321, 322, 323, 324, 325, 326, 327, 328, 329, 330, 331, 332, 333, 334, 335, 336, 337, 338, 339, 340, 341, 342, 343, 344, 345, 346, 347, 348, 349, 350, 351, 352, 353, 354, 355, 356, 357, 358, 359, 360, 361, 362, 363, 364, 365, 366, 367, 368, 369, 370, 371, 372, 373, 374, 375, 376, 377, 378, 379, 380, 381, 382, 383, 384, 385, 386, 387, 388, 389, 390, 391, 392, 393, 394, 395, 396, 397, 398, 399, 400, 401, 402, 403, 404, 405, 406, 407, 408, 409, 410, 411, 412, 413, 414, 415, 416, 417, 418, 419, 420, 421, 422, 423, 424, 425, 426, 427, 428, 429, 430, 431, 432, 433, 434, 435, 436, 437, 438, 439, 440, 441, 442, 443, 444, 445, 446, 447, 448, 449, 450, 451, 452, 453, 454, 455, 456, 457, 458, 459, 460, 461, 462, 463, 464, 465, 466, 467, 468, 469, 470, 471, 472, 473, 474, 475, 476, 477, 478, 479, 480, 481, 482, 483, 484, 485, 486, 487, 488, 489, 490, 491, 492, 493, 494, 495, 496, 497, 498, 499, 500, 501, 502, 503, 504, 505, 506, 507, 508, 509, 510, 511},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector512(bytes.Repeat([]byte{0xff}, 65)),
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range Bitvector512(bytes.Repeat([]byte{0xff}, 64)).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector512_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector512
+		want      error
+	}{
+		{
+			bitvector: NewBitvector512(),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector512(bytes.Repeat([]byte{0xff}, 64)),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector512{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector512(make([]byte, 63)),
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector512(make([]byte, 65)),
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
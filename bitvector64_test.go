@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -286,54 +287,74 @@ func TestBitvector64_Shift(t *testing.T) {
 			want:      Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
 		},
 		{
-			bitvector: Bitvector64{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
+			bitvector: Bitvector64{0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad},
 			shift:     1,
-			want:      Bitvector64{0x02, 0x47, 0xC5, 0xFD, 0xBB, 0x59, 0x5B, 0x5A},
+			want:      Bitvector64{0x02, 0x46, 0xc4, 0xfd, 0xbb, 0x59, 0x5b, 0x5b},
 		},
 		{
-			bitvector: Bitvector64{0x23, 0x01, 0xAD, 0xE2, 0xDD, 0xFE, 0xAC, 0xAD},
+			bitvector: Bitvector64{0x23, 0x01, 0xad, 0xe2, 0xdd, 0xfe, 0xac, 0xad},
 			shift:     1,
-			want:      Bitvector64{0x46, 0x03, 0x5b, 0xc5, 0xBB, 0xFD, 0x59, 0x5A},
+			want:      Bitvector64{0x46, 0x02, 0x5a, 0xc5, 0xbb, 0xfd, 0x59, 0x5b},
 		},
 		{
-			bitvector: Bitvector64{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
+			bitvector: Bitvector64{0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad},
 			shift:     -1,
-			want:      Bitvector64{0x00, 0x91, 0xf1, 0x7f, 0x6e, 0xd6, 0x56, 0xd6},
+			want:      Bitvector64{0x80, 0x11, 0x71, 0xff, 0x6e, 0xd6, 0xd6, 0x56},
 		},
 		{
-			bitvector: Bitvector64{0xd6, 0x23, 0x6e, 0x91, 0xDD, 0xAC, 0x7f, 0xE2},
+			bitvector: Bitvector64{0xd6, 0x23, 0x6e, 0x91, 0xdd, 0xac, 0x7f, 0xe2},
 			shift:     -1,
-			want:      Bitvector64{0x6b, 0x11, 0xb7, 0x48, 0xee, 0xd6, 0x3f, 0xf1},
+			want:      Bitvector64{0xeb, 0x11, 0xb7, 0xc8, 0x6e, 0xd6, 0x3f, 0x71},
 		},
 		{
-			bitvector: Bitvector64{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
+			bitvector: Bitvector64{0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad},
 			shift:     3,
-			want:      Bitvector64{0x09, 0x1f, 0x17, 0xf6, 0xed, 0x65, 0x6d, 0x68},
+			want:      Bitvector64{0x08, 0x18, 0x11, 0xf7, 0xef, 0x66, 0x6d, 0x6d},
 		},
 		{
-			bitvector: Bitvector64{0x17, 0xDD, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed},
+			bitvector: Bitvector64{0x17, 0xdd, 0x09, 0x17, 0x1f, 0x17, 0xf6, 0xed},
 			shift:     -3,
-			want:      Bitvector64{0x02, 0xfb, 0xa1, 0x22, 0xe3, 0xe2, 0xfe, 0xdd},
+			want:      Bitvector64{0xa2, 0x3b, 0xe1, 0xe2, 0xe3, 0xc2, 0xbe, 0x1d},
 		},
 		{
-			bitvector: Bitvector64{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
+			bitvector: Bitvector64{0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad},
 			shift:     8,
-			want:      Bitvector64{0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad, 0xad, 0x00},
+			want:      Bitvector64{0x00, 0x01, 0x23, 0xe2, 0xfe, 0xdd, 0xac, 0xad},
 		},
 		{
-			bitvector: Bitvector64{0x80, 0x91, 0xf1, 0x7f, 0x6e, 0xd6, 0x56, 0xd6},
+			bitvector: Bitvector64{0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e},
+			shift:     63,
+			want:      Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80},
+		},
+		{
+			bitvector: Bitvector64{0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e},
+			shift:     -63,
+			want:      Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			bitvector: Bitvector64{0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e},
+			shift:     64,
+			want:      Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			bitvector: Bitvector64{0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e},
+			shift:     -64,
+			want:      Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			bitvector: Bitvector64{0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e},
 			shift:     256,
 			want:      Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
 		},
 		{
-			bitvector: Bitvector64{0x80, 0x91, 0xf1, 0x7f, 0x6e, 0xd6, 0x56, 0xd6},
+			bitvector: Bitvector64{0x0b, 0x30, 0x55, 0x7a, 0x9f, 0xc4, 0xe9, 0x0e},
 			shift:     -256,
 			want:      Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
 		},
 	}
 
 	for _, tt := range tests {
-		original := make(Bitvector4, len(tt.bitvector))
+		original := make(Bitvector64, len(tt.bitvector))
 		copy(original, tt.bitvector)
 
 		tt.bitvector.Shift(tt.shift)
@@ -347,6 +368,154 @@ func TestBitvector64_Shift(t *testing.T) {
 			)
 		}
 	}
+
+	t.Run("wrong length", func(t *testing.T) {
+		bv := Bitvector64{0x01, 0x02}
+		bv.Shift(1)
+		if !bytes.Equal(bv, Bitvector64{0x01, 0x02}) {
+			t.Errorf("Shift() modified bitvector of wrong length: %x", bv)
+		}
+	})
+}
+
+func TestBitvector64_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector64
+		shift     int
+		want      Bitvector64
+	}{
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     0,
+			want:      Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     1,
+			want:      Bitvector64{0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     -1,
+			want:      Bitvector64{0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     3,
+			want:      Bitvector64{0xdc, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     63,
+			want:      Bitvector64{0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     64,
+			want:      Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     65,
+			want:      Bitvector64{0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     -65,
+			want:      Bitvector64{0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     131,
+			want:      Bitvector64{0xdc, 0x04, 0x2e, 0x57, 0x78, 0xa1, 0xca, 0xf3},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector64, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector64_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector64
+		shift     int
+		want      Bitvector64
+	}{
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     0,
+			want:      Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     1,
+			want:      Bitvector64{0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     -1,
+			want:      Bitvector64{0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     3,
+			want:      Bitvector64{0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     63,
+			want:      Bitvector64{0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     64,
+			want:      Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     65,
+			want:      Bitvector64{0x4d, 0xe0, 0x72, 0x85, 0x17, 0xaa, 0x3c, 0xcf},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     -65,
+			want:      Bitvector64{0x37, 0x81, 0xcb, 0x15, 0x5e, 0xa8, 0xf2, 0x3c},
+		},
+		{
+			bitvector: Bitvector64{0x9b, 0xc0, 0xe5, 0x0a, 0x2f, 0x54, 0x79, 0x9e},
+			shift:     131,
+			want:      Bitvector64{0x13, 0xb8, 0x5c, 0xe1, 0x85, 0x2a, 0xcf, 0x73},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector64, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateRight(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
 }
 
 func TestBitVector64_BitIndices(t *testing.T) {
@@ -387,3 +556,88 @@ func TestBitVector64_BitIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestBitvector64_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector64
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63},
+		},
+		{
+			bitvector: Bitvector64{0x35, 0x6a, 0x9f, 0xd4, 0x09, 0x3e, 0x73, 0xa8},
+			want:      []uint64{0, 2, 4, 5, 9, 11, 13, 14, 16, 17, 18, 19, 20, 23, 26, 28, 30, 31, 32, 35, 41, 42, 43, 44, 45, 48, 49, 52, 53, 54, 59, 61, 63},
+			wantUnset: []uint64{1, 3, 6, 7, 8, 10, 12, 15, 21, 22, 24, 25, 27, 29, 33, 34, 36, 37, 38, 39, 40, 46, 47, 50, 51, 55, 56, 57, 58, 60, 62},
+		},
+		{
+			bitvector: Bitvector64{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			want:      []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector64(bytes.Repeat([]byte{0xff}, 9)),
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range Bitvector64(bytes.Repeat([]byte{0xff}, 8)).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector64_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector64
+		want      error
+	}{
+		{
+			bitvector: NewBitvector64(),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector64{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector64{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector64{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector64(make([]byte, 9)),
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
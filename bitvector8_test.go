@@ -3,6 +3,7 @@ package bitfield
 import (
 	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -285,6 +286,216 @@ func TestBitvector8_Bytes(t *testing.T) {
 	}
 }
 
+func TestBitvector8_Shift(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector8
+		shift     int
+		want      Bitvector8
+	}{
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     0,
+			want:      Bitvector8{0x9b},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     1,
+			want:      Bitvector8{0x36},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     3,
+			want:      Bitvector8{0xd8},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -1,
+			want:      Bitvector8{0x4d},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -5,
+			want:      Bitvector8{0x04},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     7,
+			want:      Bitvector8{0x80},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -7,
+			want:      Bitvector8{0x01},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     8,
+			want:      Bitvector8{0x00},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -8,
+			want:      Bitvector8{0x00},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector8, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.Shift(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).Shift(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector8_RotateLeft(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector8
+		shift     int
+		want      Bitvector8
+	}{
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     0,
+			want:      Bitvector8{0x9b},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     1,
+			want:      Bitvector8{0x37},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -1,
+			want:      Bitvector8{0xcd},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     3,
+			want:      Bitvector8{0xdc},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     7,
+			want:      Bitvector8{0xcd},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     8,
+			want:      Bitvector8{0x9b},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     9,
+			want:      Bitvector8{0x37},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -9,
+			want:      Bitvector8{0xcd},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     19,
+			want:      Bitvector8{0xdc},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector8, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateLeft(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateLeft(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
+func TestBitvector8_RotateRight(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector8
+		shift     int
+		want      Bitvector8
+	}{
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     0,
+			want:      Bitvector8{0x9b},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     1,
+			want:      Bitvector8{0xcd},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -1,
+			want:      Bitvector8{0x37},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     3,
+			want:      Bitvector8{0x73},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     7,
+			want:      Bitvector8{0x37},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     8,
+			want:      Bitvector8{0x9b},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     9,
+			want:      Bitvector8{0xcd},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     -9,
+			want:      Bitvector8{0x37},
+		},
+		{
+			bitvector: Bitvector8{0x9b},
+			shift:     19,
+			want:      Bitvector8{0x73},
+		},
+	}
+
+	for _, tt := range tests {
+		original := make(Bitvector8, len(tt.bitvector))
+		copy(original, tt.bitvector)
+
+		tt.bitvector.RotateRight(tt.shift)
+		if !bytes.Equal(tt.bitvector, tt.want) {
+			t.Errorf(
+				"(%x).RotateRight(%d) = %x, wanted %x",
+				original,
+				tt.shift,
+				tt.bitvector,
+				tt.want,
+			)
+		}
+	}
+}
+
 func TestBitvector8_BitIndices(t *testing.T) {
 	tests := []struct {
 		a    Bitvector8
@@ -449,3 +660,84 @@ func TestBitVector8_Or(t *testing.T) {
 		}
 	}
 }
+
+func TestBitvector8_All(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector8
+		want      []uint64
+		wantUnset []uint64
+	}{
+		{
+			bitvector: Bitvector8{0x00},
+			want:      []uint64{},
+			wantUnset: []uint64{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			bitvector: Bitvector8{0x35},
+			want:      []uint64{0, 2, 4, 5},
+			wantUnset: []uint64{1, 3, 6, 7},
+		},
+		{
+			bitvector: Bitvector8{0xff},
+			want:      []uint64{0, 1, 2, 3, 4, 5, 6, 7},
+			wantUnset: []uint64{},
+		},
+		{
+			bitvector: Bitvector8{0xff, 0xff},
+			want:      []uint64{},
+			wantUnset: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := slices.Collect(tt.bitvector.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("(%x).All() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+		if got := slices.Collect(tt.bitvector.AllUnset()); !slices.Equal(got, tt.wantUnset) {
+			t.Errorf("(%x).AllUnset() = %v, wanted %v", tt.bitvector, got, tt.wantUnset)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var got []uint64
+		for idx := range Bitvector8(bytes.Repeat([]byte{0xff}, 1)).All() {
+			got = append(got, idx)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []uint64{0, 1}) {
+			t.Errorf("All() with break = %v, wanted %v", got, []uint64{0, 1})
+		}
+	})
+}
+
+func TestBitvector8_Validate(t *testing.T) {
+	tests := []struct {
+		bitvector Bitvector8
+		want      error
+	}{
+		{
+			bitvector: NewBitvector8(),
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector8{0xff},
+			want:      nil,
+		},
+		{
+			bitvector: Bitvector8{},
+			want:      ErrWrongLen,
+		},
+		{
+			bitvector: Bitvector8{0x00, 0x00},
+			want:      ErrWrongLen,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bitvector.Validate(); got != tt.want {
+			t.Errorf("(%x).Validate() = %v, wanted %v", tt.bitvector, got, tt.want)
+		}
+	}
+}
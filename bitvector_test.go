@@ -0,0 +1,426 @@
+package bitfield
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+// size3 and size100 are sizes which do not fill a whole number of bytes.
+type size3 struct{}
+
+func (size3) Bits() uint64 { return 3 }
+
+type size100 struct{}
+
+func (size100) Bits() uint64 { return 100 }
+
+func TestBitvector_Sizes(t *testing.T) {
+	type bitvector interface {
+		Bitfield
+		Validate() error
+		HashTreeRoot() ([32]byte, error)
+	}
+	tests := []struct {
+		bitvector bitvector
+		wantLen   uint64
+		wantBytes int
+	}{
+		{bitvector: NewBitvector[size3](), wantLen: 3, wantBytes: 1},
+		{bitvector: NewBitvector16(), wantLen: 16, wantBytes: 2},
+		{bitvector: NewBitvector[size100](), wantLen: 100, wantBytes: 13},
+		{bitvector: NewBitvector1024(), wantLen: 1024, wantBytes: 128},
+	}
+
+	for _, tt := range tests {
+		bv := tt.bitvector
+		if bv.Len() != tt.wantLen {
+			t.Errorf("(%x).Len() = %d, wanted %d", bv, bv.Len(), tt.wantLen)
+		}
+		if len(bv.Bytes()) != tt.wantBytes {
+			t.Errorf("len((%x).Bytes()) = %d, wanted %d", bv, len(bv.Bytes()), tt.wantBytes)
+		}
+		if err := bv.Validate(); err != nil {
+			t.Errorf("(%x).Validate() = %v, wanted nil", bv, err)
+		}
+		bv.SetBitAt(0, true)
+		bv.SetBitAt(tt.wantLen-1, true)
+		bv.SetBitAt(tt.wantLen, true) // Out of bounds, ignored.
+		if !bv.BitAt(0) || !bv.BitAt(tt.wantLen-1) || bv.BitAt(tt.wantLen) {
+			t.Errorf("(%x).BitAt() returned wrong values after SetBitAt()", bv)
+		}
+		if want := []int{0, int(tt.wantLen) - 1}; !reflect.DeepEqual(bv.BitIndices(), want) {
+			t.Errorf("(%x).BitIndices() = %v, wanted %v", bv, bv.BitIndices(), want)
+		}
+		if bv.Count() != 2 {
+			t.Errorf("(%x).Count() = %d, wanted 2", bv, bv.Count())
+		}
+	}
+
+	t.Run("padding", func(t *testing.T) {
+		bv := Bitvector[size3]{0xfa} // 0b11111010, bits=[0,1,0]
+		if err := bv.Validate(); err != ErrBitvectorNonzeroPadding {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitvectorNonzeroPadding, err)
+		}
+		if got := bv.Bytes(); !reflect.DeepEqual(got, []byte{0x02}) {
+			t.Errorf("(%x).Bytes() = %x, wanted %x", bv, got, []byte{0x02})
+		}
+		if bv.Count() != 1 {
+			t.Errorf("(%x).Count() = %d, wanted 1", bv, bv.Count())
+		}
+		if ok, err := bv.Contains(Bitvector[size3]{0x0a}); !ok || err != nil {
+			t.Errorf("(%x).Contains(0a) = %t, %v, wanted true, nil", bv, ok, err)
+		}
+		if ok, err := (Bitvector[size3]{0x02}).Overlaps(Bitvector[size3]{0xf8}); ok || err != nil {
+			t.Errorf("(02).Overlaps(f8) = %t, %v, wanted false, nil", ok, err)
+		}
+		bv.Shift(1)
+		if !reflect.DeepEqual(bv, Bitvector[size3]{0x04}) {
+			t.Errorf("Shift(1) = %x, wanted %x", bv, []byte{0x04})
+		}
+	})
+
+	t.Run("hash tree root", func(t *testing.T) {
+		bv := NewBitvector1024()
+		for i := range bv {
+			bv[i] = byte(i)
+		}
+		root, err := bv.HashTreeRoot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "f70de27c568e0eaa9b81092527e654cdd60131da968ceb9ce562212f407a62ad"; hex.EncodeToString(root[:]) != want {
+			t.Errorf("HashTreeRoot() = %x, wanted %s", root, want)
+		}
+
+		odd := NewBitvector[size100]()
+		odd.SetBitAt(0, true)
+		odd.SetBitAt(99, true)
+		root, err = odd.HashTreeRoot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "0100000000000000000000000800000000000000000000000000000000000000"; hex.EncodeToString(root[:]) != want {
+			t.Errorf("HashTreeRoot() = %x, wanted %s", root, want)
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		if _, err := (Bitvector16{0x01}).Or(Bitvector16{0x01, 0x02}); err != ErrBitvectorDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitvectorDifferentLength, err)
+		}
+		if err := (Bitvector1024{0x01}).Validate(); err != ErrWrongLen {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+		}
+	})
+}
+
+func TestBitvector_NextPrev(t *testing.T) {
+	patterns := []byte{0x00, 0xff, 0x01, 0x80, 0x5a, 0xa5, 0x10}
+	for _, p := range patterns {
+		for _, bv := range []bitScanner{
+			Bitvector2{p}, Bitvector4{p}, Bitvector8{p},
+			Bitvector32(fill(p, 4)), Bitvector64(fill(p, 8)), Bitvector128(fill(p, 16)),
+			Bitvector256(fill(p, 32)), Bitvector512(fill(p, 64)), Bitvector[size3]{p},
+			Bitvector16(fill(p, 2)), Bitvector[size100](fill(p, 13)), Bitvector1024(fill(p, 128)),
+		} {
+			checkNextPrev(t, bv)
+		}
+	}
+
+	// Set a single bit in an otherwise empty bitvector and look for it from both ends.
+	bv := NewBitvector512()
+	bv.SetBitAt(300, true)
+	if idx, ok := bv.NextSet(0); !ok || idx != 300 {
+		t.Errorf("NextSet(0) = %d, %t, wanted 300, true", idx, ok)
+	}
+	if idx, ok := bv.PrevSet(511); !ok || idx != 300 {
+		t.Errorf("PrevSet(511) = %d, %t, wanted 300, true", idx, ok)
+	}
+	if idx, ok := bv.NextSet(301); ok {
+		t.Errorf("NextSet(301) = %d, true, wanted false", idx)
+	}
+
+	t.Run("wrong length", func(t *testing.T) {
+		for _, bv := range []bitScanner{
+			Bitvector2{}, Bitvector4{0xff, 0xff}, Bitvector8{}, Bitvector32{0xff}, Bitvector64{0xff},
+			Bitvector128{0xff}, Bitvector256{0xff}, Bitvector512{0xff},
+		} {
+			if _, ok := bv.NextSet(0); ok {
+				t.Errorf("(%x).NextSet(0) returned true for wrong length", bv)
+			}
+			if _, ok := bv.NextClear(0); ok {
+				t.Errorf("(%x).NextClear(0) returned true for wrong length", bv)
+			}
+			if _, ok := bv.PrevSet(0); ok {
+				t.Errorf("(%x).PrevSet(0) returned true for wrong length", bv)
+			}
+			if _, ok := bv.PrevClear(0); ok {
+				t.Errorf("(%x).PrevClear(0) returned true for wrong length", bv)
+			}
+		}
+	})
+}
+
+// fill returns n bytes set to b.
+func fill(b byte, n int) []byte {
+	ret := make([]byte, n)
+	for i := range ret {
+		ret[i] = b
+	}
+	return ret
+}
+
+func TestBitvector_SetOps(t *testing.T) {
+	tests := []struct {
+		a, b        Bitvector8
+		and, or     Bitvector8
+		xor, andNot Bitvector8
+		not         Bitvector8
+	}{
+		{
+			a:      Bitvector8{0x00},
+			b:      Bitvector8{0x00},
+			and:    Bitvector8{0x00},
+			or:     Bitvector8{0x00},
+			xor:    Bitvector8{0x00},
+			andNot: Bitvector8{0x00},
+			not:    Bitvector8{0xff},
+		},
+		{
+			a:      Bitvector8{0x13}, // 0b00010011
+			b:      Bitvector8{0x15}, // 0b00010101
+			and:    Bitvector8{0x11},
+			or:     Bitvector8{0x17},
+			xor:    Bitvector8{0x06},
+			andNot: Bitvector8{0x02},
+			not:    Bitvector8{0xec},
+		},
+		{
+			a:      Bitvector8{0xff},
+			b:      Bitvector8{0x0f},
+			and:    Bitvector8{0x0f},
+			or:     Bitvector8{0xff},
+			xor:    Bitvector8{0xf0},
+			andNot: Bitvector8{0xf0},
+			not:    Bitvector8{0x00},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, op := range []struct {
+			name    string
+			fn      func(Bitvector8) (Bitvector8, error)
+			noAlloc func(c, ret Bitvector8) error
+			count   func(Bitvector8) (uint64, error)
+			want    Bitvector8
+		}{
+			{"And", tt.a.And, tt.a.NoAllocAnd, tt.a.AndCount, tt.and},
+			{"Or", tt.a.Or, tt.a.NoAllocOr, tt.a.OrCount, tt.or},
+			{"Xor", tt.a.Xor, tt.a.NoAllocXor, tt.a.XorCount, tt.xor},
+			{"AndNot", tt.a.AndNot, tt.a.NoAllocAndNot, tt.a.AndNotCount, tt.andNot},
+		} {
+			if got, err := op.fn(tt.b); err != nil || !reflect.DeepEqual(got, op.want) {
+				t.Errorf("(%x).%s(%x) = %x, %v, wanted %x", tt.a, op.name, tt.b, got, err, op.want)
+			}
+			ret := NewBitvector8()
+			if err := op.noAlloc(tt.b, ret); err != nil || !reflect.DeepEqual(ret, op.want) {
+				t.Errorf("(%x).NoAlloc%s(%x) = %x, %v, wanted %x", tt.a, op.name, tt.b, ret, err, op.want)
+			}
+			if got, err := op.count(tt.b); err != nil || got != op.want.Count() {
+				t.Errorf("(%x).%sCount(%x) = %d, %v, wanted %d", tt.a, op.name, tt.b, got, err, op.want.Count())
+			}
+		}
+		if got, err := tt.a.Not(); err != nil || !reflect.DeepEqual(got, tt.not) {
+			t.Errorf("(%x).Not() = %x, %v, wanted %x", tt.a, got, err, tt.not)
+		}
+		ret := NewBitvector8()
+		if err := tt.a.NoAllocNot(ret); err != nil || !reflect.DeepEqual(ret, tt.not) {
+			t.Errorf("(%x).NoAllocNot() = %x, %v, wanted %x", tt.a, ret, err, tt.not)
+		}
+	}
+
+	t.Run("padding", func(t *testing.T) {
+		// Padding bits are ignored by counts and cleared in results.
+		a, b := Bitvector4{0xf3}, Bitvector4{0xa5}
+		if got, err := a.Not(); err != nil || !reflect.DeepEqual(got, Bitvector4{0x0c}) {
+			t.Errorf("(%x).Not() = %x, %v, wanted %x", a, got, err, []byte{0x0c})
+		}
+		if got, err := a.Or(b); err != nil || !reflect.DeepEqual(got, Bitvector4{0x07}) {
+			t.Errorf("(%x).Or(%x) = %x, %v, wanted %x", a, b, got, err, []byte{0x07})
+		}
+		if got, err := a.AndCount(b); err != nil || got != 1 {
+			t.Errorf("(%x).AndCount(%x) = %d, %v, wanted 1", a, b, got, err)
+		}
+		if got, err := a.XorCount(b); err != nil || got != 2 {
+			t.Errorf("(%x).XorCount(%x) = %d, %v, wanted 2", a, b, got, err)
+		}
+	})
+
+	t.Run("multiple bytes", func(t *testing.T) {
+		a, b := NewBitvector512(), NewBitvector512()
+		for _, idx := range []uint64{0, 100, 300, 511} {
+			a.SetBitAt(idx, true)
+		}
+		for _, idx := range []uint64{100, 200, 511} {
+			b.SetBitAt(idx, true)
+		}
+		and, err := a.And(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []int{100, 511}; !reflect.DeepEqual(and.BitIndices(), want) {
+			t.Errorf("And() = %v, wanted %v", and.BitIndices(), want)
+		}
+		andNot, err := a.AndNot(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []int{0, 300}; !reflect.DeepEqual(andNot.BitIndices(), want) {
+			t.Errorf("AndNot() = %v, wanted %v", andNot.BitIndices(), want)
+		}
+		if got, err := a.OrCount(b); err != nil || got != 5 {
+			t.Errorf("OrCount() = %d, %v, wanted 5", got, err)
+		}
+		not, err := a.Not()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if not.Count() != 508 {
+			t.Errorf("Not().Count() = %d, wanted 508", not.Count())
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		full, short := NewBitvector64(), Bitvector64{0x01}
+		if _, err := full.And(short); err != ErrBitvectorDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitvectorDifferentLength, err)
+		}
+		if err := full.NoAllocOr(full, short); err != ErrBitvectorDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitvectorDifferentLength, err)
+		}
+		// Operands of the same length are accepted, even if it is not the expected one.
+		if got, err := short.XorCount(short); got != 0 || err != nil {
+			t.Errorf("XorCount() = %d, %v, wanted 0, nil", got, err)
+		}
+		if got, err := short.Contains(short); !got || err != nil {
+			t.Errorf("Contains() = %t, %v, wanted true, nil", got, err)
+		}
+		if _, err := short.Overlaps(full); err != ErrBitvectorDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitvectorDifferentLength, err)
+		}
+		if got, err := short.Not(); !reflect.DeepEqual(got, Bitvector64{0xfe}) || err != nil {
+			t.Errorf("Not() = %x, %v, wanted fe, nil", got, err)
+		}
+		if err := full.NoAllocNot(short); err != ErrBitvectorDifferentLength {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitvectorDifferentLength, err)
+		}
+		if got, err := (Bitvector2{}).AndNot(Bitvector2{}); len(got) != 0 || err != nil {
+			t.Errorf("AndNot() = %x, %v, wanted empty, nil", got, err)
+		}
+	})
+}
+
+func TestBitvector_Range(t *testing.T) {
+	checkRangeOps(t, NewBitvector[size3]())
+	checkRangeOps(t, NewBitvector8())
+	checkRangeOps(t, NewBitvector[size100]())
+	checkRangeOps(t, NewBitvector512())
+
+	t.Run("padding", func(t *testing.T) {
+		b := NewBitvector[size3]()
+		if err := b.FlipRange(0, 3); err != nil {
+			t.Fatal(err)
+		}
+		if b[0] != 0x07 {
+			t.Errorf("FlipRange(0, 3) = %x, wanted 07", b)
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		b := Bitvector8{0x00, 0x00}
+		if err := b.SetRange(0, 1, true); err != ErrWrongLen {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+		}
+	})
+}
+
+func TestBitvector_TryBitAt(t *testing.T) {
+	checkStrictAccessors(t, NewBitvector[size3]())
+	checkStrictAccessors(t, NewBitvector8())
+	checkStrictAccessors(t, NewBitvector[size100]())
+
+	t.Run("check errors", func(t *testing.T) {
+		b := Bitvector8{0x01, 0x00}
+		if _, err := b.TryBitAt(0); err != ErrWrongLen {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+		}
+		if err := b.TrySetBitAt(0, true); err != ErrWrongLen {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+		}
+	})
+}
+
+func TestBitvector_InPlace(t *testing.T) {
+	tests := []struct {
+		a Bitvector[size3]
+		b Bitvector[size3]
+	}{
+		{a: Bitvector[size3]{0x00}, b: Bitvector[size3]{0x07}},
+		{a: Bitvector[size3]{0x05}, b: Bitvector[size3]{0x06}},
+		{a: Bitvector[size3]{0xfd}, b: Bitvector[size3]{0x03}}, // Padding bits are cleared.
+	}
+
+	for _, tt := range tests {
+		for _, op := range []struct {
+			name    string
+			inPlace func(a, b Bitvector[size3]) error
+			alloc   func(a, b Bitvector[size3]) (Bitvector[size3], error)
+		}{
+			{"Or", Bitvector[size3].OrInPlace, Bitvector[size3].Or},
+			{"And", Bitvector[size3].AndInPlace, Bitvector[size3].And},
+			{"Xor", Bitvector[size3].XorInPlace, Bitvector[size3].Xor},
+			{"AndNot", Bitvector[size3].AndNotInPlace, Bitvector[size3].AndNot},
+		} {
+			want, err := op.alloc(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Bitvector[size3]{tt.a[0]}
+			if err := op.inPlace(got, tt.b); err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("(%x).%sInPlace(%x) = %x, %v, wanted %x", tt.a, op.name, tt.b, got, err, want)
+			}
+
+			// The receiver and the argument may be the same bitvector.
+			want, err = op.alloc(tt.a, tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = Bitvector[size3]{tt.a[0]}
+			if err := op.inPlace(got, got); err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("(%x).%sInPlace(itself) = %x, %v, wanted %x", tt.a, op.name, got, err, want)
+			}
+		}
+
+		got := Bitvector[size3]{tt.a[0]}
+		want, err := tt.a.Not()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := got.NotInPlace(); err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("(%x).NotInPlace() = %x, %v, wanted %x", tt.a, got, err, want)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, short := NewBitvector128(), Bitvector128{0x01}
+		for _, fn := range []func(Bitvector128) error{a.OrInPlace, a.AndInPlace, a.XorInPlace, a.AndNotInPlace} {
+			if err := fn(short); err != ErrBitvectorDifferentLength {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitvectorDifferentLength, err)
+			}
+		}
+		if err := short.NotInPlace(); err != nil || !reflect.DeepEqual(short, Bitvector128{0xfe}) {
+			t.Errorf("NotInPlace() = %x, %v, wanted fe, nil", short, err)
+		}
+	})
+}
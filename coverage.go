@@ -0,0 +1,84 @@
+package bitfield
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// MaxCover greedily selects up to k of the candidate bitlists, each time picking the one which adds
+// the most bits not yet covered by the previous picks, and returns the indices of the chosen
+// candidates in the order they were picked together with the union of their bits.
+//
+// If weights is not nil, it must hold one weight per candidate, and the number of new bits each
+// candidate adds is multiplied by its weight, so candidates with a zero weight are never picked.
+// Ties go to the candidate with the lowest index. Selection stops early once no candidate adds
+// any new bits.
+//
+// The chosen indices and the union are the only allocations. NoAllocMaxCover can be used to reuse
+// them across calls. This function will return an error if the candidates are not the same length.
+func MaxCover(candidates []*Bitlist64, k int, weights []uint64) ([]int, *Bitlist64, error) {
+	if weights != nil && len(weights) != len(candidates) {
+		return nil, nil, ErrWeightsMismatch
+	}
+	if len(candidates) == 0 {
+		return []int{}, NewBitlist64(0), nil
+	}
+
+	union := NewBitlist64(candidates[0].Len())
+	selected, err := NoAllocMaxCover(candidates, k, weights, union, make([]int, 0, min(max(k, 0), len(candidates))))
+	if err != nil {
+		return nil, nil, err
+	}
+	return selected, union, nil
+}
+
+// NoAllocMaxCover computes the same selection as MaxCover. The union is written into provided
+// variable, which must be the same length as the candidates, and the chosen indices are appended
+// to selected[:0], so no allocation takes place inside the function as long as selected has a
+// capacity of at least k.
+func NoAllocMaxCover(candidates []*Bitlist64, k int, weights []uint64, union *Bitlist64, selected []int) ([]int, error) {
+	selected = selected[:0]
+	if weights != nil && len(weights) != len(candidates) {
+		return selected, ErrWeightsMismatch
+	}
+	for i, c := range candidates {
+		if c.Len() != union.Len() {
+			return selected, fmt.Errorf("%w: candidates[%d] has length %d, wanted %d", ErrBitlistDifferentLength, i, c.Len(), union.Len())
+		}
+	}
+
+	for idx := range union.data {
+		union.data[idx] = 0
+	}
+	for len(selected) < k {
+		// Scores are 128 bit products of the gain and the weight, so they cannot overflow.
+		best, bestHi, bestLo := -1, uint64(0), uint64(0)
+		for i, c := range candidates {
+			weight := uint64(1)
+			if weights != nil {
+				weight = weights[i]
+			}
+			if weight == 0 {
+				continue
+			}
+			// Chosen candidates are fully covered by the union, so they score zero.
+			var gain int
+			for idx := range c.data {
+				gain += bits.OnesCount64(c.maskedWord(uint64(idx), false) &^ union.data[idx])
+			}
+			if hi, lo := bits.Mul64(uint64(gain), weight); hi > bestHi || (hi == bestHi && lo > bestLo) {
+				best, bestHi, bestLo = i, hi, lo
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		selected = append(selected, best)
+		for idx := range candidates[best].data {
+			union.data[idx] |= candidates[best].maskedWord(uint64(idx), false)
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,150 @@
+package bitfield
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMaxCover(t *testing.T) {
+	candidates := []*Bitlist64{
+		NewBitlist64From([]uint64{0x0f}), // 0b00001111
+		NewBitlist64From([]uint64{0xf0}), // 0b11110000
+		NewBitlist64From([]uint64{0x3c}), // 0b00111100
+		NewBitlist64From([]uint64{0x01}), // 0b00000001
+		NewBitlist64From([]uint64{0x7e}), // 0b01111110
+	}
+
+	tests := []struct {
+		name      string
+		k         int
+		weights   []uint64
+		want      []int
+		wantUnion uint64
+	}{
+		{
+			name:      "zero budget",
+			k:         0,
+			want:      []int{},
+			wantUnion: 0x00,
+		},
+		{
+			name:      "largest first",
+			k:         1,
+			want:      []int{4},
+			wantUnion: 0x7e,
+		},
+		{
+			name:      "marginal gains",
+			k:         2,
+			want:      []int{4, 0},
+			wantUnion: 0x7f,
+		},
+		{
+			name:      "stops when nothing new is covered",
+			k:         10,
+			want:      []int{4, 0, 1},
+			wantUnion: 0xff,
+		},
+		{
+			name:      "weights",
+			k:         2,
+			weights:   []uint64{1, 1, 1, 10, 1},
+			want:      []int{3, 4},
+			wantUnion: 0x7f,
+		},
+		{
+			name:      "zero weights are never picked",
+			k:         10,
+			weights:   []uint64{0, 1, 0, 0, 0},
+			want:      []int{1},
+			wantUnion: 0xf0,
+		},
+		{
+			name:      "ties go to the lowest index",
+			k:         1,
+			weights:   []uint64{3, 3, 1, 1, 1},
+			want:      []int{0},
+			wantUnion: 0x0f,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, union, err := MaxCover(candidates, tt.k, tt.weights)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MaxCover(%d, %v) = %v, wanted %v", tt.k, tt.weights, got, tt.want)
+			}
+			if want := NewBitlist64From([]uint64{tt.wantUnion}); !reflect.DeepEqual(union, want) {
+				t.Errorf("MaxCover(%d, %v) union = %+v, wanted %+v", tt.k, tt.weights, union, want)
+			}
+		})
+	}
+
+	t.Run("no candidates", func(t *testing.T) {
+		got, union, err := MaxCover(nil, 3, nil)
+		if err != nil || len(got) != 0 || union.Len() != 0 {
+			t.Errorf("MaxCover(nil) = %v, %+v, %v, wanted empty", got, union, err)
+		}
+	})
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size add no coverage, and are left out of the union.
+		dirty, err := NewBitlist64FromBytes(3, []byte{0xf9})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clean := &Bitlist64{size: 3, data: []uint64{0x06}}
+		got, union, err := MaxCover([]*Bitlist64{dirty, clean}, 1, nil)
+		if err != nil || !reflect.DeepEqual(got, []int{1}) || !reflect.DeepEqual(union, clean) {
+			t.Errorf("MaxCover() = %v, %+v, %v, wanted [1], %+v", got, union, err, clean)
+		}
+	})
+
+	t.Run("large weights", func(t *testing.T) {
+		// The score of the first candidate, 2 * 2^63, does not fit in 64 bits.
+		a := NewBitlist64From([]uint64{0x03})
+		b := NewBitlist64From([]uint64{0x0c})
+		got, _, err := MaxCover([]*Bitlist64{a, b}, 1, []uint64{1 << 63, 3})
+		if err != nil || !reflect.DeepEqual(got, []int{0}) {
+			t.Errorf("MaxCover() = %v, %v, wanted [0]", got, err)
+		}
+	})
+
+	t.Run("no allocations", func(t *testing.T) {
+		union := NewBitlist64(64)
+		selected := make([]int, 0, 3)
+		allocs := testing.AllocsPerRun(10, func() {
+			if _, err := NoAllocMaxCover(candidates, 3, nil, union, selected); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("NoAllocMaxCover() allocated %v times, wanted 0", allocs)
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		if _, _, err := MaxCover(candidates, 1, []uint64{1}); err != ErrWeightsMismatch {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWeightsMismatch, err)
+		}
+		if _, _, err := MaxCover(nil, 1, []uint64{1}); err != ErrWeightsMismatch {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWeightsMismatch, err)
+		}
+		mixed := []*Bitlist64{NewBitlist64(64), NewBitlist64(64), NewBitlist64(128)}
+		_, _, err := MaxCover(mixed, 1, nil)
+		if !errors.Is(err, ErrBitlistDifferentLength) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "candidates[2]") {
+			t.Errorf("Error %q does not name the offending argument candidates[2]", err)
+		}
+		if _, err := NoAllocMaxCover(candidates, 1, nil, NewBitlist64(128), nil); !errors.Is(err, ErrBitlistDifferentLength) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+	})
+}
@@ -14,4 +14,12 @@
 // Whereas the bitlist can be created with size N at runtime. The bitlist uses
 // the most significant bit in little endian order to indicate the start of the
 // bitlist while in the byte representation.
+//
+// Every BitvectorN is an alias of the generic Bitvector type instantiated with a
+// Size, e.g. Bitvector64 is Bitvector[Size64]. Other sizes are supported by
+// declaring a new Size type.
+//
+// RoaringBitlist is a compressed alternative to Bitlist64 for large bitlists which
+// are nearly all zeros or nearly all ones. It converts losslessly to and from the
+// other bitlists.
 package bitfield
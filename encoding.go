@@ -0,0 +1,246 @@
+package bitfield
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// This file holds the text and JSON encodings of the bitfields, matching the beacon API: a 0x
+// prefixed hex string of the SSZ bytes. Bitlists include the length bit, while bitvectors are
+// exactly as long as their size requires, with zero padding bits.
+//
+// Bitlist64 also has a binary encoding, used for gob, made of a version byte, the size as an
+// unsigned varint and the words in little endian order.
+
+// bitlist64BinaryVersion is the version byte of the Bitlist64 binary encoding.
+const bitlist64BinaryVersion = 1
+
+var (
+	_ encoding.TextMarshaler   = Bitlist{}
+	_ encoding.TextUnmarshaler = &Bitlist{}
+	_ json.Marshaler           = Bitlist{}
+	_ json.Unmarshaler         = &Bitlist{}
+	_ encoding.TextMarshaler   = &Bitlist64{}
+	_ encoding.TextUnmarshaler = &Bitlist64{}
+	_ json.Marshaler           = &Bitlist64{}
+	_ json.Unmarshaler         = &Bitlist64{}
+	_ encoding.TextMarshaler   = Bitvector8{}
+	_ encoding.TextUnmarshaler = &Bitvector8{}
+	_ json.Marshaler           = Bitvector8{}
+	_ json.Unmarshaler         = &Bitvector8{}
+
+	_ encoding.BinaryMarshaler   = &Bitlist64{}
+	_ encoding.BinaryUnmarshaler = &Bitlist64{}
+	_ gob.GobEncoder             = &Bitlist64{}
+	_ gob.GobDecoder             = &Bitlist64{}
+)
+
+// MarshalText returns the bitlist as a 0x prefixed hex string. This method will return an error if
+// the bitlist has no length bit.
+func (b Bitlist) MarshalText() ([]byte, error) {
+	if _, err := BitlistFromSSZ(b, math.MaxUint64); err != nil {
+		return nil, err
+	}
+	return encodeHex(b), nil
+}
+
+// UnmarshalText sets the bitlist from a 0x prefixed hex string, checking that it is a well formed
+// bitlist. See BitlistFromSSZ for the errors returned.
+func (b *Bitlist) UnmarshalText(text []byte) error {
+	data, err := decodeHex(text)
+	if err != nil {
+		return err
+	}
+	bl, err := BitlistFromSSZ(data, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	*b = bl
+	return nil
+}
+
+// MarshalJSON returns the bitlist as a JSON string holding MarshalText.
+func (b Bitlist) MarshalJSON() ([]byte, error) {
+	return marshalJSON(b)
+}
+
+// UnmarshalJSON sets the bitlist from a JSON string, see UnmarshalText.
+func (b *Bitlist) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, b)
+}
+
+// MarshalText returns the bitlist as a 0x prefixed hex string of its SSZ encoding.
+func (b *Bitlist64) MarshalText() ([]byte, error) {
+	return encodeHex(b.masked().ToBitlist()), nil
+}
+
+// UnmarshalText sets the bitlist from a 0x prefixed hex string of its SSZ encoding, checking that
+// it is a well formed bitlist. See BitlistFromSSZ for the errors returned.
+func (b *Bitlist64) UnmarshalText(text []byte) error {
+	var bl Bitlist
+	if err := bl.UnmarshalText(text); err != nil {
+		return err
+	}
+	bl64, err := bl.ToBitlist64()
+	if err != nil {
+		return err
+	}
+	*b = *bl64
+	return nil
+}
+
+// MarshalJSON returns the bitlist as a JSON string holding MarshalText.
+func (b *Bitlist64) MarshalJSON() ([]byte, error) {
+	return marshalJSON(b)
+}
+
+// UnmarshalJSON sets the bitlist from a JSON string, see UnmarshalText.
+func (b *Bitlist64) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, b)
+}
+
+// MarshalBinary returns the binary encoding of the bitlist.
+func (b *Bitlist64) MarshalBinary() ([]byte, error) {
+	return b.AppendBinary(make([]byte, 0, 1+binary.MaxVarintLen64+numWordsRequired(b.size)*bytesInWord))
+}
+
+// AppendBinary appends the binary encoding of the bitlist to data, returning the extended slice.
+func (b *Bitlist64) AppendBinary(data []byte) ([]byte, error) {
+	data = append(data, bitlist64BinaryVersion)
+	data = binary.AppendUvarint(data, b.size)
+	words := b.data[:numWordsRequired(b.size)]
+	for idx, word := range words {
+		if idx == len(words)-1 && b.size%wordSize != 0 {
+			// Leave out any unused bits set past the size.
+			word &= allBitsSet >> (wordSize - b.size%wordSize)
+		}
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary sets the bitlist from its binary encoding. This method will return
+// ErrUnsupportedVersion if the encoding has an unknown version, and ErrInvalidEncoding if it is
+// truncated, has trailing bytes or has bits set past the size of the bitlist.
+func (b *Bitlist64) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: no version byte", ErrInvalidEncoding)
+	}
+	if data[0] != bitlist64BinaryVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[0])
+	}
+	size, n := binary.Uvarint(data[1:])
+	if n <= 0 {
+		return fmt.Errorf("%w: bad size", ErrInvalidEncoding)
+	}
+	data = data[1+n:]
+	// Compare word counts rather than bytes, so that a huge size cannot overflow.
+	numWords := size >> wordSizeLog2
+	if size%wordSize != 0 {
+		numWords++
+	}
+	if uint64(len(data))%bytesInWord != 0 || uint64(len(data))/bytesInWord != numWords {
+		return fmt.Errorf("%w: %d bytes of words for size %d", ErrInvalidEncoding, len(data), size)
+	}
+
+	words := make([]uint64, len(data)/bytesInWord)
+	for idx := range words {
+		words[idx] = binary.LittleEndian.Uint64(data[idx*bytesInWord:])
+	}
+	if size%wordSize != 0 && words[len(words)-1]>>(size%wordSize) != 0 {
+		return fmt.Errorf("%w: bits set past size %d", ErrInvalidEncoding, size)
+	}
+	b.size, b.data = size, words
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding of the bitlist.
+func (b *Bitlist64) GobEncode() ([]byte, error) {
+	return b.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding of the bitlist.
+func (b *Bitlist64) GobDecode(data []byte) error {
+	return b.UnmarshalBinary(data)
+}
+
+// MarshalText returns the bitvector as a 0x prefixed hex string. This method will return an error
+// if the bitvector is not valid, see Validate.
+func (b Bitvector[S]) MarshalText() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return encodeHex(b), nil
+}
+
+// UnmarshalText sets the bitvector from a 0x prefixed hex string. This method will return
+// ErrWrongLen if the decoded bitvector is not the expected length, and ErrBitvectorNonzeroPadding
+// if any of its padding bits is set.
+func (b *Bitvector[S]) UnmarshalText(text []byte) error {
+	data, err := decodeHex(text)
+	if err != nil {
+		return err
+	}
+	if err := validateBitvector(data, bitSize[S]()); err != nil {
+		return err
+	}
+	*b = data
+	return nil
+}
+
+// MarshalJSON returns the bitvector as a JSON string holding MarshalText.
+func (b Bitvector[S]) MarshalJSON() ([]byte, error) {
+	return marshalJSON(b)
+}
+
+// UnmarshalJSON sets the bitvector from a JSON string, see UnmarshalText.
+func (b *Bitvector[S]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, b)
+}
+
+// encodeHex returns b as a 0x prefixed hex string.
+func encodeHex(b []byte) []byte {
+	ret := make([]byte, 2+hex.EncodedLen(len(b)))
+	copy(ret, "0x")
+	hex.Encode(ret[2:], b)
+	return ret
+}
+
+// decodeHex returns the bytes held in a 0x prefixed hex string.
+func decodeHex(text []byte) ([]byte, error) {
+	if len(text) < 2 || text[0] != '0' || (text[1] != 'x' && text[1] != 'X') {
+		return nil, ErrMissingHexPrefix
+	}
+	ret := make([]byte, hex.DecodedLen(len(text)-2))
+	if _, err := hex.Decode(ret, text[2:]); err != nil {
+		return nil, fmt.Errorf("invalid hex string: %w", err)
+	}
+	return ret, nil
+}
+
+// marshalJSON returns the text encoding of m as a JSON string.
+func marshalJSON(m encoding.TextMarshaler) ([]byte, error) {
+	text, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// unmarshalJSON decodes a JSON string into u with its text encoding. A JSON null leaves u
+// unchanged.
+func unmarshalJSON(data []byte, u encoding.TextUnmarshaler) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(text))
+}
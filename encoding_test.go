@@ -0,0 +1,296 @@
+package bitfield
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBitlist_MarshalText(t *testing.T) {
+	tests := []struct {
+		b    Bitlist
+		want string
+	}{
+		{b: Bitlist{0x01}, want: "0x01"},
+		{b: Bitlist{0x0d}, want: "0x0d"},
+		{b: Bitlist{0xff, 0x01}, want: "0xff01"},
+		{b: Bitlist{0x00, 0xa0, 0x02}, want: "0x00a002"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.b.MarshalText()
+		if err != nil || string(got) != tt.want {
+			t.Errorf("(%x).MarshalText() = %s, %v, wanted %s", tt.b, got, err, tt.want)
+		}
+
+		var b Bitlist
+		if err := b.UnmarshalText([]byte(tt.want)); err != nil || !bytes.Equal(b, tt.b) {
+			t.Errorf("UnmarshalText(%s) = %x, %v, wanted %x", tt.want, b, err, tt.b)
+		}
+
+		b64, err := tt.b.ToBitlist64()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = b64.MarshalText()
+		if err != nil || string(got) != tt.want {
+			t.Errorf("(%+v).MarshalText() = %s, %v, wanted %s", b64, got, err, tt.want)
+		}
+
+		var gotB64 Bitlist64
+		if err := gotB64.UnmarshalText([]byte(tt.want)); err != nil || !reflect.DeepEqual(&gotB64, b64) {
+			t.Errorf("UnmarshalText(%s) = %+v, %v, wanted %+v", tt.want, gotB64, err, b64)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			text string
+			err  error
+		}{
+			{text: "", err: ErrMissingHexPrefix},
+			{text: "0d", err: ErrMissingHexPrefix},
+			{text: "0x", err: ErrBitlistMissingDelimiter},
+			{text: "0x0d00", err: ErrBitlistTrailingBytes},
+			{text: "0x0000", err: ErrBitlistMissingDelimiter},
+			{text: "0xzz", err: nil},
+		} {
+			var b Bitlist
+			err := b.UnmarshalText([]byte(tt.text))
+			if err == nil || (tt.err != nil && err != tt.err) {
+				t.Errorf("UnmarshalText(%q) wrong error returned. Wanted %v, got %v", tt.text, tt.err, err)
+			}
+			var b64 Bitlist64
+			if err := b64.UnmarshalText([]byte(tt.text)); err == nil || (tt.err != nil && err != tt.err) {
+				t.Errorf("Bitlist64 UnmarshalText(%q) wrong error returned. Wanted %v, got %v", tt.text, tt.err, err)
+			}
+		}
+		if _, err := (Bitlist{}).MarshalText(); err != ErrBitlistMissingDelimiter {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistMissingDelimiter, err)
+		}
+	})
+}
+
+func TestBitlist64_MarshalText_UnusedBits(t *testing.T) {
+	// Bits set past the size are left out, so the text holds a well formed bitlist.
+	b, err := NewBitlist64FromBytes(3, []byte{0xfb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Bitlist64{size: 3, data: []uint64{0x03}}
+
+	text, err := b.MarshalText()
+	if err != nil || string(text) != "0x0b" {
+		t.Errorf("(%+v).MarshalText() = %s, %v, wanted 0x0b", b, text, err)
+	}
+	var got Bitlist64
+	if err := got.UnmarshalText(text); err != nil || !reflect.DeepEqual(&got, want) {
+		t.Errorf("UnmarshalText(%s) = %+v, %v, wanted %+v", text, &got, err, want)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = Bitlist64{}
+	if err := json.Unmarshal(data, &got); err != nil || !reflect.DeepEqual(&got, want) {
+		t.Errorf("json.Unmarshal(%s) = %+v, %v, wanted %+v", data, &got, err, want)
+	}
+}
+
+func TestBitvector_MarshalText(t *testing.T) {
+	b := Bitvector[size3]{0x05}
+	got, err := b.MarshalText()
+	if err != nil || string(got) != "0x05" {
+		t.Errorf("(%x).MarshalText() = %s, %v, wanted 0x05", b, got, err)
+	}
+
+	var b512 Bitvector512
+	want := NewBitvector512()
+	want.SetBitAt(0, true)
+	want.SetBitAt(511, true)
+	text, err := want.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b512.UnmarshalText(text); err != nil || !bytes.Equal(b512, want) {
+		t.Errorf("UnmarshalText(%s) = %x, %v, wanted %x", text, b512, err, want)
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			text string
+			err  error
+		}{
+			{text: "05", err: ErrMissingHexPrefix},
+			{text: "0x", err: ErrWrongLen},
+			{text: "0x0500", err: ErrWrongLen},
+			{text: "0x0d", err: ErrBitvectorNonzeroPadding},
+		} {
+			var b Bitvector[size3]
+			if err := b.UnmarshalText([]byte(tt.text)); err != tt.err {
+				t.Errorf("UnmarshalText(%q) wrong error returned. Wanted %v, got %v", tt.text, tt.err, err)
+			}
+		}
+		if _, err := (Bitvector8{0x01, 0x00}).MarshalText(); err != ErrWrongLen {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+		}
+	})
+}
+
+func TestMarshalJSON(t *testing.T) {
+	type attestation struct {
+		AggregationBits   Bitlist     `json:"aggregation_bits"`
+		Participation     *Bitlist64  `json:"participation"`
+		CommitteeBits     Bitvector64 `json:"committee_bits"`
+		SyncCommitteeBits Bitvector4  `json:"sync_committee_bits"`
+	}
+
+	want := attestation{
+		AggregationBits:   Bitlist{0x0d},
+		Participation:     NewBitlist64From([]uint64{0x03}),
+		CommitteeBits:     Bitvector64{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80},
+		SyncCommitteeBits: Bitvector4{0x0a},
+	}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"aggregation_bits":"0x0d","participation":"0x030000000000000001","committee_bits":"0x0100000000000080","sync_committee_bits":"0x0a"}`
+	if string(data) != wantJSON {
+		t.Errorf("json.Marshal() = %s, wanted %s", data, wantJSON)
+	}
+
+	var got attestation
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal() = %+v, wanted %+v", got, want)
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		var got attestation
+		err := json.Unmarshal([]byte(`{"committee_bits":"0x01"}`), &got)
+		if !errors.Is(err, ErrWrongLen) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+		}
+		if err := json.Unmarshal([]byte(`{"aggregation_bits":13}`), &got); err == nil {
+			t.Error("Expected error for a JSON number")
+		}
+	})
+}
+
+func TestBitlist64_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		b    *Bitlist64
+		want []byte
+	}{
+		{
+			b:    NewBitlist64(0),
+			want: []byte{0x01, 0x00},
+		},
+		{
+			b:    &Bitlist64{size: 3, data: []uint64{0x05}},
+			want: []byte{0x01, 0x03, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			b: NewBitlist64From([]uint64{0x0102030405060708, allBitsSet, 0x00}),
+			want: []byte{
+				0x01, 0xc0, 0x01,
+				0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01,
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.b.MarshalBinary()
+		if err != nil || !bytes.Equal(got, tt.want) {
+			t.Errorf("(%v).MarshalBinary() = %x, %v, wanted %x", tt.b, got, err, tt.want)
+		}
+
+		got, err = tt.b.AppendBinary([]byte{0xaa})
+		if err != nil || !bytes.Equal(got, append([]byte{0xaa}, tt.want...)) {
+			t.Errorf("(%v).AppendBinary() = %x, %v, wanted aa%x", tt.b, got, err, tt.want)
+		}
+
+		var b Bitlist64
+		if err := b.UnmarshalBinary(tt.want); err != nil || !reflect.DeepEqual(&b, tt.b) {
+			t.Errorf("UnmarshalBinary(%x) = %v, %v, wanted %v", tt.want, &b, err, tt.b)
+		}
+	}
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size are left out, so the encoding can be decoded.
+		b, err := NewBitlist64FromBytes(3, []byte{0xfb})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &Bitlist64{size: 3, data: []uint64{0x03}}
+
+		data, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Bitlist64
+		if err := got.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(&got, want) {
+			t.Errorf("UnmarshalBinary(%x) = %v, %v, wanted %v", data, &got, err, want)
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(b); err != nil {
+			t.Fatal(err)
+		}
+		got = Bitlist64{}
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil || !reflect.DeepEqual(&got, want) {
+			t.Errorf("gob round trip = %v, %v, wanted %v", &got, err, want)
+		}
+	})
+
+	t.Run("gob", func(t *testing.T) {
+		type snapshot struct {
+			Epoch         uint64
+			Participation *Bitlist64
+		}
+		want := snapshot{Epoch: 7, Participation: NewBitlist64(100)}
+		want.Participation.SetBitAt(99, true)
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+			t.Fatal(err)
+		}
+		var got snapshot
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("gob round trip = %+v, wanted %+v", got, want)
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			data []byte
+			err  error
+		}{
+			{data: nil, err: ErrInvalidEncoding},
+			{data: []byte{0x02, 0x00}, err: ErrUnsupportedVersion},
+			{data: []byte{0x01}, err: ErrInvalidEncoding},
+			{data: []byte{0x01, 0x80}, err: ErrInvalidEncoding},
+			{data: []byte{0x01, 0x03, 0x05}, err: ErrInvalidEncoding},
+			{data: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, err: ErrInvalidEncoding},
+			{data: []byte{0x01, 0x03, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, err: ErrInvalidEncoding},
+			{data: []byte{0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, err: ErrInvalidEncoding},
+		} {
+			var b Bitlist64
+			if err := b.UnmarshalBinary(tt.data); !errors.Is(err, tt.err) {
+				t.Errorf("UnmarshalBinary(%x) wrong error returned. Wanted %v, got %v", tt.data, tt.err, err)
+			}
+		}
+	})
+}
@@ -1,9 +1,40 @@
 package bitfield
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrBitlistDifferentLength   = errors.New("bitlists are different lengths")
 	ErrBitvectorDifferentLength = errors.New("bitvectors are different lengths")
 	ErrWrongLen                 = errors.New("bitvector is wrong length")
+	ErrBitvectorNonzeroPadding  = errors.New("bitvector has nonzero padding bits")
+	ErrBitlistExceedsLimit      = errors.New("bitlist exceeds limit")
+	ErrBitlistMissingDelimiter  = errors.New("bitlist is missing length bit")
+	ErrBitlistTrailingBytes     = errors.New("bitlist has trailing bytes after length bit")
+	ErrInvalidProof             = errors.New("invalid merkle proof")
+	ErrBitNotSet                = errors.New("bit is not set")
+	ErrNoBitIndices             = errors.New("no bit indices to prove")
+	ErrWeightsMismatch          = errors.New("number of weights does not match number of candidates")
+	ErrUnknownPartitionMode     = errors.New("unknown partition mode")
+	ErrInvalidRange             = errors.New("bit range is out of bounds")
+	ErrMissingHexPrefix         = errors.New("hex string is missing 0x prefix")
+	ErrInvalidBitString         = errors.New("bit string may only hold 0, 1 and _")
+	ErrUnsupportedVersion       = errors.New("unsupported encoding version")
+	ErrInvalidEncoding          = errors.New("malformed binary encoding")
+	ErrChecksumMismatch         = errors.New("encoding checksum mismatch")
 )
+
+// ErrIndexOutOfRange is returned by the strict accessors of a bitfield when the requested index is
+// not below its length.
+type ErrIndexOutOfRange struct {
+	// Index is the requested index.
+	Index uint64
+	// Len is the length of the bitfield.
+	Len uint64
+}
+
+func (e *ErrIndexOutOfRange) Error() string {
+	return fmt.Sprintf("index %d out of range for bitfield of length %d", e.Index, e.Len)
+}
@@ -0,0 +1,343 @@
+package bitfield
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// This file holds a compressed encoding of Bitlist64 for storage and transfer, in the style of
+// EWAH (Enhanced Word-Aligned Hybrid). The words of the bitlist are written as a sequence of
+// markers, each made of a run of fill words (all zeros or all ones) followed by literal words
+// copied as is. The layout is:
+//
+//	version  1 byte, ewahVersion
+//	size     unsigned varint, the number of bits in the bitlist
+//	markers  for each marker:
+//	           unsigned varint, the number of fill words << 1 | 1 for ones or 0 for zeros
+//	           unsigned varint, the number of literal words
+//	           the literal words, 8 bytes each in little endian order
+//	checksum 4 bytes, CRC-32C of everything above in little endian order
+//
+// A marker holds at least one word. OrEWAH and AndEWAH combine encoded bitlists marker by marker,
+// without decoding them.
+
+// ewahVersion is the version byte of the EWAH encoding.
+const ewahVersion = 1
+
+// ewahChecksumSize is the number of bytes of the checksum at the end of the EWAH encoding.
+const ewahChecksumSize = 4
+
+// castagnoli is the CRC-32C table used for the checksum of the EWAH encoding.
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// EncodeEWAH returns the EWAH encoding of the bitlist.
+func EncodeEWAH(b *Bitlist64) []byte {
+	w := newEWAHWriter(b.size)
+	words := b.data[:numWordsRequired(b.size)]
+	for idx, word := range words {
+		if idx == len(words)-1 && b.size%wordSize != 0 {
+			// Leave out any unused bits set past the size.
+			word &= allBitsSet >> (wordSize - b.size%wordSize)
+		}
+		w.addWords(word, 1)
+	}
+	return w.finish()
+}
+
+// DecodeEWAH returns the bitlist held in the EWAH encoded data, checking that it holds at most
+// limit bits. As a few bytes of fill words can describe a huge bitlist, limit bounds the memory
+// allocated for the result. This function will return ErrChecksumMismatch if the data is
+// corrupted, ErrUnsupportedVersion if it has an unknown version, ErrBitlistExceedsLimit if the
+// bitlist is longer than limit, and ErrInvalidEncoding if its words do not match the size of the
+// bitlist.
+func DecodeEWAH(data []byte, limit uint64) (*Bitlist64, error) {
+	r, err := newEWAHReader(data)
+	if err != nil {
+		return nil, err
+	}
+	if r.size > limit {
+		return nil, ErrBitlistExceedsLimit
+	}
+
+	numWords, err := r.countWords()
+	if err != nil {
+		return nil, err
+	}
+	if numWords != r.numWords {
+		return nil, fmt.Errorf("%w: %d words for size %d", ErrInvalidEncoding, numWords, r.size)
+	}
+
+	b := NewBitlist64(r.size)
+	for idx := 0; idx < len(b.data); {
+		word, n, err := r.peek()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(b.data)-idx) {
+			return nil, fmt.Errorf("%w: more words than size %d requires", ErrInvalidEncoding, r.size)
+		}
+		for end := idx + int(n); idx < end; idx++ {
+			b.data[idx] = word
+		}
+		r.advance(n)
+	}
+	if err := r.checkEnd(); err != nil {
+		return nil, err
+	}
+	if len(b.data) > 0 {
+		if err := r.checkLastWord(b.data[len(b.data)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// OrEWAH returns the EWAH encoding of the OR result of two EWAH encoded bitlists (union). This
+// function will return an error if the bitlists are not the same length, or if either encoding is
+// invalid, see DecodeEWAH.
+func OrEWAH(a, b []byte) ([]byte, error) {
+	return combineEWAH(a, b, opOr)
+}
+
+// AndEWAH returns the EWAH encoding of the AND result of two EWAH encoded bitlists
+// (intersection). This function will return an error if the bitlists are not the same length, or
+// if either encoding is invalid, see DecodeEWAH.
+func AndEWAH(a, b []byte) ([]byte, error) {
+	return combineEWAH(a, b, opAnd)
+}
+
+// combineEWAH returns the EWAH encoding of op applied to two EWAH encoded bitlists. Runs of fill
+// words in both operands are combined at once.
+func combineEWAH(a, b []byte, op setOp) ([]byte, error) {
+	ra, err := newEWAHReader(a)
+	if err != nil {
+		return nil, err
+	}
+	rb, err := newEWAHReader(b)
+	if err != nil {
+		return nil, err
+	}
+	if ra.size != rb.size {
+		return nil, ErrBitlistDifferentLength
+	}
+
+	w := newEWAHWriter(ra.size)
+	for left := ra.numWords; left > 0; {
+		wordA, nA, err := ra.peek()
+		if err != nil {
+			return nil, err
+		}
+		wordB, nB, err := rb.peek()
+		if err != nil {
+			return nil, err
+		}
+
+		n := nA
+		if nB < n {
+			n = nB
+		}
+		if n > left {
+			return nil, fmt.Errorf("%w: more words than size %d requires", ErrInvalidEncoding, ra.size)
+		}
+		if n == left {
+			// The words hold the last word of both bitlists.
+			if err := ra.checkLastWord(wordA); err != nil {
+				return nil, err
+			}
+			if err := rb.checkLastWord(wordB); err != nil {
+				return nil, err
+			}
+		}
+		switch op {
+		case opOr:
+			w.addWords(wordA|wordB, n)
+		case opAnd:
+			w.addWords(wordA&wordB, n)
+		}
+		ra.advance(n)
+		rb.advance(n)
+		left -= n
+	}
+	if err := ra.checkEnd(); err != nil {
+		return nil, err
+	}
+	if err := rb.checkEnd(); err != nil {
+		return nil, err
+	}
+	return w.finish(), nil
+}
+
+// ewahWriter builds an EWAH encoding, merging consecutive fill words into runs.
+type ewahWriter struct {
+	buf []byte
+	// fill and runLen are the fill word and the number of fill words of the pending marker.
+	fill   uint64
+	runLen uint64
+	// literals are the literal words of the pending marker.
+	literals []uint64
+}
+
+// newEWAHWriter returns a writer for the encoding of a bitlist of the given size.
+func newEWAHWriter(size uint64) *ewahWriter {
+	w := &ewahWriter{}
+	w.buf = append(w.buf, ewahVersion)
+	w.buf = binary.AppendUvarint(w.buf, size)
+	return w
+}
+
+// addWords adds n copies of word to the encoding.
+func (w *ewahWriter) addWords(word, n uint64) {
+	if word != 0 && word != allBitsSet {
+		for ; n > 0; n-- {
+			w.literals = append(w.literals, word)
+		}
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// A fill can only extend the pending marker while it has no literals.
+	if len(w.literals) > 0 || (w.runLen > 0 && w.fill != word) {
+		w.flush()
+	}
+	w.fill = word
+	w.runLen += n
+}
+
+// flush writes the pending marker.
+func (w *ewahWriter) flush() {
+	if w.runLen == 0 && len(w.literals) == 0 {
+		return
+	}
+	w.buf = binary.AppendUvarint(w.buf, w.runLen<<1|w.fill&1)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(w.literals)))
+	for _, word := range w.literals {
+		w.buf = binary.LittleEndian.AppendUint64(w.buf, word)
+	}
+	w.runLen, w.literals = 0, w.literals[:0]
+}
+
+// finish writes the pending marker and the checksum, returning the encoding.
+func (w *ewahWriter) finish() []byte {
+	w.flush()
+	return binary.LittleEndian.AppendUint32(w.buf, crc32.Checksum(w.buf, castagnoli))
+}
+
+// ewahReader reads the words of an EWAH encoding one fill run or literal word at a time.
+type ewahReader struct {
+	size uint64
+	// numWords is the number of words needed to hold size bits.
+	numWords uint64
+	// data holds the markers which are left, without the checksum.
+	data []byte
+	// fill and runLen are the fill word and the number of fill words left in the current marker.
+	fill   uint64
+	runLen uint64
+	// numLiterals is the number of literal words left in the current marker.
+	numLiterals uint64
+}
+
+// newEWAHReader checks the checksum and version of the EWAH encoded data, returning a reader for
+// its words.
+func newEWAHReader(data []byte) (*ewahReader, error) {
+	if len(data) < 1+ewahChecksumSize {
+		return nil, fmt.Errorf("%w: %d bytes is too short", ErrInvalidEncoding, len(data))
+	}
+	body, checksum := data[:len(data)-ewahChecksumSize], data[len(data)-ewahChecksumSize:]
+	if crc32.Checksum(body, castagnoli) != binary.LittleEndian.Uint32(checksum) {
+		return nil, ErrChecksumMismatch
+	}
+	if body[0] != ewahVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, body[0])
+	}
+	size, n := binary.Uvarint(body[1:])
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: bad size", ErrInvalidEncoding)
+	}
+	numWords := size >> wordSizeLog2
+	if size%wordSize != 0 {
+		numWords++
+	}
+	return &ewahReader{size: size, numWords: numWords, data: body[1+n:]}, nil
+}
+
+// peek returns the current fill word with the number of fill words left in its run, or the
+// current literal word with a count of 1, without consuming them.
+func (r *ewahReader) peek() (uint64, uint64, error) {
+	for r.runLen == 0 && r.numLiterals == 0 {
+		if len(r.data) == 0 {
+			return 0, 0, fmt.Errorf("%w: fewer words than size %d requires", ErrInvalidEncoding, r.size)
+		}
+		fill, n := binary.Uvarint(r.data)
+		if n <= 0 {
+			return 0, 0, fmt.Errorf("%w: bad marker", ErrInvalidEncoding)
+		}
+		numLiterals, m := binary.Uvarint(r.data[n:])
+		if m <= 0 {
+			return 0, 0, fmt.Errorf("%w: bad marker", ErrInvalidEncoding)
+		}
+		r.data = r.data[n+m:]
+		if fill>>1 == 0 && numLiterals == 0 {
+			return 0, 0, fmt.Errorf("%w: empty marker", ErrInvalidEncoding)
+		}
+		if numLiterals > uint64(len(r.data))/bytesInWord {
+			return 0, 0, fmt.Errorf("%w: marker has %d literal words, %d bytes left", ErrInvalidEncoding, numLiterals, len(r.data))
+		}
+		r.fill, r.runLen, r.numLiterals = 0, fill>>1, numLiterals
+		if fill&1 == 1 {
+			r.fill = allBitsSet
+		}
+	}
+
+	if r.runLen > 0 {
+		return r.fill, r.runLen, nil
+	}
+	return binary.LittleEndian.Uint64(r.data), 1, nil
+}
+
+// advance consumes n words, which must not be more than the count returned by peek.
+func (r *ewahReader) advance(n uint64) {
+	if r.runLen > 0 {
+		r.runLen -= n
+		return
+	}
+	r.data = r.data[bytesInWord:]
+	r.numLiterals--
+}
+
+// countWords returns the number of words in the encoding, without consuming them.
+func (r ewahReader) countWords() (uint64, error) {
+	var total uint64
+	for r.runLen > 0 || r.numLiterals > 0 || len(r.data) > 0 {
+		// Load the next marker, then skip all of its words.
+		if _, _, err := r.peek(); err != nil {
+			return 0, err
+		}
+		for _, n := range []uint64{r.runLen, r.numLiterals} {
+			if total += n; total < n {
+				return 0, fmt.Errorf("%w: too many words", ErrInvalidEncoding)
+			}
+		}
+		r.data = r.data[r.numLiterals*bytesInWord:]
+		r.runLen, r.numLiterals = 0, 0
+	}
+	return total, nil
+}
+
+// checkLastWord returns an error if the last word of the bitlist has bits set past its size.
+func (r *ewahReader) checkLastWord(word uint64) error {
+	if r.size%wordSize != 0 && word>>(r.size%wordSize) != 0 {
+		return fmt.Errorf("%w: bits set past size %d", ErrInvalidEncoding, r.size)
+	}
+	return nil
+}
+
+// checkEnd returns an error unless all words of the encoding have been read.
+func (r *ewahReader) checkEnd() error {
+	if r.runLen > 0 || r.numLiterals > 0 || len(r.data) > 0 {
+		return fmt.Errorf("%w: more words than size %d requires", ErrInvalidEncoding, r.size)
+	}
+	return nil
+}
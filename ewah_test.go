@@ -0,0 +1,181 @@
+package bitfield
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// withEWAHChecksum returns body followed by its EWAH checksum.
+func withEWAHChecksum(body ...byte) []byte {
+	return binary.LittleEndian.AppendUint32(body, crc32.Checksum(body, castagnoli))
+}
+
+func TestEncodeEWAH(t *testing.T) {
+	tests := []struct {
+		b    *Bitlist64
+		want []byte
+	}{
+		{
+			b:    NewBitlist64(0),
+			want: withEWAHChecksum(0x01, 0x00),
+		},
+		{
+			b:    NewBitlist64(200),
+			want: withEWAHChecksum(0x01, 0xc8, 0x01, 0x08, 0x00),
+		},
+		{
+			b: NewBitlist64From([]uint64{allBitsSet, allBitsSet, 0x05, 0x00, 0x00}),
+			want: withEWAHChecksum(
+				0x01, 0xc0, 0x02,
+				0x05, 0x01, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x04, 0x00,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		got := EncodeEWAH(tt.b)
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("EncodeEWAH(%v) = %x, wanted %x", tt.b, got, tt.want)
+		}
+		b, err := DecodeEWAH(got, tt.b.Len())
+		if err != nil || !reflect.DeepEqual(b, tt.b) {
+			t.Errorf("DecodeEWAH(%x) = %v, %v, wanted %v", got, b, err, tt.b)
+		}
+	}
+
+	for _, n := range []uint64{1, 64, 100, 200000} {
+		for name, b := range roaringFixtures(n) {
+			data := EncodeEWAH(b)
+			got, err := DecodeEWAH(data, n)
+			if err != nil || !reflect.DeepEqual(got, b) {
+				t.Errorf("%s/%d: DecodeEWAH() does not match the original bitlist, err=%v", name, n, err)
+			}
+			if name == "all set" && len(data) > 16 {
+				t.Errorf("%s/%d: encoding is %d bytes, wanted a single marker", name, n, len(data))
+			}
+		}
+	}
+}
+
+func TestEncodeEWAH_UnusedBits(t *testing.T) {
+	// Bits set past the size are left out, so the encoding can be decoded.
+	b, err := NewBitlist64FromBytes(3, []byte{0xfb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Bitlist64{size: 3, data: []uint64{0x03}}
+	got, err := DecodeEWAH(EncodeEWAH(b), 3)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeEWAH(EncodeEWAH(%v)) = %v, %v, wanted %v", b, got, err, want)
+	}
+}
+
+func TestOrAndEWAH(t *testing.T) {
+	for _, n := range []uint64{100, 200000} {
+		fixtures := roaringFixtures(n)
+		for nameA, a := range fixtures {
+			for nameB, b := range fixtures {
+				for _, op := range []struct {
+					name  string
+					dense func(a, b *Bitlist64) (*Bitlist64, error)
+					ewah  func(a, b []byte) ([]byte, error)
+				}{
+					{"Or", (*Bitlist64).Or, OrEWAH},
+					{"And", (*Bitlist64).And, AndEWAH},
+				} {
+					want, err := op.dense(a, b)
+					if err != nil {
+						t.Fatal(err)
+					}
+					got, err := op.ewah(EncodeEWAH(a), EncodeEWAH(b))
+					if err != nil {
+						t.Fatal(err)
+					}
+					// The result is encoded as compactly as a fresh encoding of the dense result.
+					if !bytes.Equal(got, EncodeEWAH(want)) {
+						t.Errorf("%d: %s %s %s does not match the dense result", n, nameA, op.name, nameB)
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		a, b := EncodeEWAH(NewBitlist64(100)), EncodeEWAH(NewBitlist64(101))
+		if _, err := OrEWAH(a, b); !errors.Is(err, ErrBitlistDifferentLength) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		short := withEWAHChecksum(0x01, 0xc8, 0x01, 0x02, 0x00)
+		if _, err := AndEWAH(EncodeEWAH(NewBitlist64(200)), short); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidEncoding, err)
+		}
+		// The last word of a 3 bit bitlist with bits set past the size.
+		dirty := withEWAHChecksum(0x01, 0x03, 0x00, 0x01, 0xfb, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+		if _, err := DecodeEWAH(dirty, 3); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidEncoding, err)
+		}
+		for _, op := range []func(a, b []byte) ([]byte, error){OrEWAH, AndEWAH} {
+			if _, err := op(EncodeEWAH(NewBitlist64(3)), dirty); !errors.Is(err, ErrInvalidEncoding) {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidEncoding, err)
+			}
+			if _, err := op(dirty, EncodeEWAH(NewBitlist64(3))); !errors.Is(err, ErrInvalidEncoding) {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidEncoding, err)
+			}
+		}
+		long := withEWAHChecksum(0x01, 0xc8, 0x01, 0x0a, 0x00)
+		if _, err := OrEWAH(EncodeEWAH(NewBitlist64(200)), long); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidEncoding, err)
+		}
+	})
+}
+
+func TestDecodeEWAH_Errors(t *testing.T) {
+	corrupted := EncodeEWAH(NewBitlist64From([]uint64{0x05}))
+	corrupted[3] ^= 0x01
+
+	tests := []struct {
+		name  string
+		data  []byte
+		limit uint64
+		err   error
+	}{
+		{name: "empty", data: nil, err: ErrInvalidEncoding},
+		{name: "corrupted", data: corrupted, err: ErrChecksumMismatch},
+		{name: "version", data: withEWAHChecksum(0x02, 0x00), err: ErrUnsupportedVersion},
+		{name: "bad size", data: withEWAHChecksum(0x01, 0x80), err: ErrInvalidEncoding},
+		{name: "too few words", data: withEWAHChecksum(0x01, 0xc8, 0x01, 0x06, 0x00), err: ErrInvalidEncoding},
+		{name: "too many words", data: withEWAHChecksum(0x01, 0x40, 0x04, 0x00), err: ErrInvalidEncoding},
+		{name: "empty marker", data: withEWAHChecksum(0x01, 0x00, 0x00, 0x00), err: ErrInvalidEncoding},
+		{name: "truncated literal", data: withEWAHChecksum(0x01, 0x40, 0x00, 0x01, 0x05), err: ErrInvalidEncoding},
+		{name: "bits past size", data: withEWAHChecksum(0x01, 0x03, 0x03, 0x00), err: ErrInvalidEncoding},
+		{
+			// A single fill marker matching a size past the limit.
+			name: "exceeds limit",
+			data: withEWAHChecksum(0x01, 0x80, 0x80, 0x80, 0x80, 0x80, 0x20, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01, 0x00),
+			err:  ErrBitlistExceedsLimit,
+		},
+		{
+			// A size within the limit, which does not match the single fill word.
+			name:  "huge size",
+			data:  withEWAHChecksum(0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x02, 0x00),
+			limit: math.MaxUint64,
+			err:   ErrInvalidEncoding,
+		},
+	}
+
+	for _, tt := range tests {
+		limit := uint64(1 << 20)
+		if tt.limit != 0 {
+			limit = tt.limit
+		}
+		if _, err := DecodeEWAH(tt.data, limit); !errors.Is(err, tt.err) {
+			t.Errorf("%s: wrong error returned. Wanted %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package bitfield
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file holds the human readable forms of the bitfields. Bits are written in index order,
+// starting with bit 0, in groups of 4 separated by underscores, e.g. "1010_0011 (len=8, count=4)".
+// The Parse functions read the same bit strings back, which keeps test fixtures readable.
+//
+// The bitfields implement fmt.Formatter with the following verbs:
+//
+//	%v, %s  the bits in index order with the length and number of bits set
+//	%d      the indices of the bits set, e.g. [0 2 6 7]
+//	%x, %X  the SSZ encoding in hex, including the length bit of bitlists
+//	%b      the SSZ encoding as a list of bytes in base 2, most significant bit first
+//
+// Flags such as # and width apply to %d, %x, %X and %b as they would to the underlying slice.
+
+var (
+	_ fmt.Formatter = Bitlist{}
+	_ fmt.Formatter = &Bitlist64{}
+	_ fmt.Formatter = Bitvector8{}
+)
+
+// String returns the bits of the bitlist in index order, followed by its length and number of
+// bits set.
+func (b Bitlist) String() string {
+	return bitString(b)
+}
+
+// Format implements fmt.Formatter, writing the bits for %v and %s, the indices of the bits set for
+// %d, and the SSZ encoding for %x, %X and %b.
+func (b Bitlist) Format(f fmt.State, verb rune) {
+	formatBitfield(f, verb, b, b)
+}
+
+// String returns the bits of the bitlist in index order, followed by its length and number of
+// bits set.
+func (b *Bitlist64) String() string {
+	return bitString(b.masked())
+}
+
+// Format implements fmt.Formatter, writing the bits for %v and %s, the indices of the bits set for
+// %d, and the SSZ encoding for %x, %X and %b.
+func (b *Bitlist64) Format(f fmt.State, verb rune) {
+	// Every verb shows the same bits, leaving out any unused bits set past the size.
+	m := b.masked()
+	formatBitfield(f, verb, m, m.ToBitlist())
+}
+
+// String returns the bits of the bitvector in index order, followed by its length and number of
+// bits set.
+func (b Bitvector[S]) String() string {
+	return bitString(b)
+}
+
+// Format implements fmt.Formatter, writing the bits for %v and %s, the indices of the bits set for
+// %d, and the SSZ encoding for %x, %X and %b.
+func (b Bitvector[S]) Format(f fmt.State, verb rune) {
+	formatBitfield(f, verb, b, b)
+}
+
+// ParseBitlist returns the bitlist holding the bits of s, given in index order. Underscores may be
+// used to group the bits, and are ignored. This function will return an error if s holds any other
+// character than 0, 1 and _.
+func ParseBitlist(s string) (Bitlist, error) {
+	n, err := countBitString(s)
+	if err != nil {
+		return nil, err
+	}
+	b := NewBitlist(n)
+	parseBitString(s, b)
+	return b, nil
+}
+
+// ParseBitlist64 returns the bitlist holding the bits of s, see ParseBitlist.
+func ParseBitlist64(s string) (*Bitlist64, error) {
+	n, err := countBitString(s)
+	if err != nil {
+		return nil, err
+	}
+	b := NewBitlist64(n)
+	parseBitString(s, b)
+	return b, nil
+}
+
+// ParseBitvector returns the bitvector holding the bits of s, see ParseBitlist. This function will
+// return ErrWrongLen if s does not hold exactly as many bits as the bitvector.
+func ParseBitvector[S Size](s string) (Bitvector[S], error) {
+	n, err := countBitString(s)
+	if err != nil {
+		return nil, err
+	}
+	if n != bitSize[S]() {
+		return nil, ErrWrongLen
+	}
+	b := NewBitvector[S]()
+	parseBitString(s, b)
+	return b, nil
+}
+
+// bitString returns the bits of b in index order, followed by its length and number of bits set.
+func bitString(b Bitfield) string {
+	var sb strings.Builder
+	n := b.Len()
+	sb.Grow(int(n + n/4 + 32))
+	for i := uint64(0); i < n; i++ {
+		if i > 0 && i%4 == 0 {
+			sb.WriteByte('_')
+		}
+		if b.BitAt(i) {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	if n > 0 {
+		sb.WriteByte(' ')
+	}
+	fmt.Fprintf(&sb, "(len=%d, count=%d)", n, b.Count())
+	return sb.String()
+}
+
+// formatBitfield writes b to f according to verb, where ssz is the SSZ encoding of b.
+func formatBitfield(f fmt.State, verb rune, b Bitfield, ssz []byte) {
+	switch verb {
+	case 'v', 's':
+		fmt.Fprint(f, bitString(b))
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), b.BitIndices())
+	case 'x', 'X', 'b':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), ssz)
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, bitString(b))
+	}
+}
+
+// countBitString returns the number of bits in s, checking that it is a valid bit string.
+func countBitString(s string) (uint64, error) {
+	var n uint64
+	for i, c := range s {
+		switch c {
+		case '0', '1':
+			n++
+		case '_':
+		default:
+			return 0, fmt.Errorf("%w: %q at position %d", ErrInvalidBitString, c, i)
+		}
+	}
+	return n, nil
+}
+
+// parseBitString sets the bits of b from the valid bit string s.
+func parseBitString(s string, b Bitfield) {
+	var idx uint64
+	for _, c := range s {
+		if c == '_' {
+			continue
+		}
+		b.SetBitAt(idx, c == '1')
+		idx++
+	}
+}
@@ -0,0 +1,154 @@
+package bitfield
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestBitlist_Format(t *testing.T) {
+	b := Bitlist{0xc5, 0x01}
+	b64, err := b.ToBitlist64()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "%v", want: "1010_0011 (len=8, count=4)"},
+		{format: "%s", want: "1010_0011 (len=8, count=4)"},
+		{format: "%d", want: "[0 2 6 7]"},
+		{format: "%x", want: "c501"},
+		{format: "%#x", want: "0xc501"},
+		{format: "%X", want: "C501"},
+		{format: "%08b", want: "[11000101 00000001]"},
+		{format: "%q", want: "%!q(1010_0011 (len=8, count=4))"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, b); got != tt.want {
+			t.Errorf("Sprintf(%q, Bitlist) = %q, wanted %q", tt.format, got, tt.want)
+		}
+		if got := fmt.Sprintf(tt.format, b64); got != tt.want {
+			t.Errorf("Sprintf(%q, Bitlist64) = %q, wanted %q", tt.format, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		b    fmt.Stringer
+		want string
+	}{
+		{b: NewBitlist(0), want: "(len=0, count=0)"},
+		{b: Bitlist{0x0d}, want: "101 (len=3, count=2)"},
+		{b: NewBitlist64From([]uint64{0x0101}), want: "1000_0000_1000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000 (len=64, count=2)"},
+		{b: Bitvector[size3]{0x06}, want: "011 (len=3, count=2)"},
+		{b: Bitvector16{0x01, 0x80}, want: "1000_0000_0000_0001 (len=16, count=2)"},
+	} {
+		if got := tt.b.String(); got != tt.want {
+			t.Errorf("String() = %q, wanted %q", got, tt.want)
+		}
+	}
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size are left out, whatever the verb.
+		b, err := NewBitlist64FromBytes(3, []byte{0xfb})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range []struct {
+			format string
+			want   string
+		}{
+			{format: "%v", want: "110 (len=3, count=2)"},
+			{format: "%d", want: "[0 1]"},
+			{format: "%x", want: "0b"},
+			{format: "%08b", want: "[00001011]"},
+		} {
+			if got := fmt.Sprintf(tt.format, b); got != tt.want {
+				t.Errorf("Sprintf(%q, Bitlist64) = %q, wanted %q", tt.format, got, tt.want)
+			}
+		}
+		if got := b.String(); got != "110 (len=3, count=2)" {
+			t.Errorf("String() = %q, wanted %q", got, "110 (len=3, count=2)")
+		}
+	})
+}
+
+func TestParseBitlist(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Bitlist
+	}{
+		{s: "", want: Bitlist{0x01}},
+		{s: "101", want: Bitlist{0x0d}},
+		{s: "10100011", want: Bitlist{0xc5, 0x01}},
+		{s: "1010_0011", want: Bitlist{0xc5, 0x01}},
+		{s: "1010_0011_1", want: Bitlist{0xc5, 0x03}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBitlist(tt.s)
+		if err != nil || !bytes.Equal(got, tt.want) {
+			t.Errorf("ParseBitlist(%q) = %x, %v, wanted %x", tt.s, got, err, tt.want)
+		}
+
+		want64, err := tt.want.ToBitlist64()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got64, err := ParseBitlist64(tt.s)
+		if err != nil || !reflect.DeepEqual(got64, want64) {
+			t.Errorf("ParseBitlist64(%q) = %v, %v, wanted %v", tt.s, got64, err, want64)
+		}
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		b := Bitlist{0xc5, 0x2e, 0x05}
+		s := fmt.Sprint(b)
+		got, err := ParseBitlist(s[:bytes.IndexByte([]byte(s), ' ')])
+		if err != nil || !bytes.Equal(got, b) {
+			t.Errorf("ParseBitlist(%q) = %x, %v, wanted %x", s, got, err, b)
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, s := range []string{"102", "1 0", "0x01"} {
+			if _, err := ParseBitlist(s); !errors.Is(err, ErrInvalidBitString) {
+				t.Errorf("ParseBitlist(%q) wrong error returned. Wanted %v, got %v", s, ErrInvalidBitString, err)
+			}
+			if _, err := ParseBitlist64(s); !errors.Is(err, ErrInvalidBitString) {
+				t.Errorf("ParseBitlist64(%q) wrong error returned. Wanted %v, got %v", s, ErrInvalidBitString, err)
+			}
+		}
+	})
+}
+
+func TestParseBitvector(t *testing.T) {
+	got, err := ParseBitvector8("1010_0011")
+	if want := (Bitvector8{0xc5}); err != nil || !bytes.Equal(got, want) {
+		t.Errorf("ParseBitvector8() = %x, %v, wanted %x", got, err, want)
+	}
+	got4, err := ParseBitvector4("0110")
+	if want := (Bitvector4{0x06}); err != nil || !bytes.Equal(got4, want) {
+		t.Errorf("ParseBitvector4() = %x, %v, wanted %x", got4, err, want)
+	}
+	got16, err := ParseBitvector16("10000000_00000001")
+	if want := (Bitvector16{0x01, 0x80}); err != nil || !bytes.Equal(got16, want) {
+		t.Errorf("ParseBitvector16() = %x, %v, wanted %x", got16, err, want)
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, s := range []string{"", "1010_001", "1010_0011_1"} {
+			if _, err := ParseBitvector8(s); err != ErrWrongLen {
+				t.Errorf("ParseBitvector8(%q) wrong error returned. Wanted %v, got %v", s, ErrWrongLen, err)
+			}
+		}
+		if _, err := ParseBitvector8("1010_001x"); !errors.Is(err, ErrInvalidBitString) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidBitString, err)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package bitfield
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	// chunkSize is the number of bytes in a single SSZ merkle chunk.
+	chunkSize = 32
+	// bitsPerChunk is the number of bits packed into a single SSZ merkle chunk.
+	bitsPerChunk = chunkSize * 8
+	// maxTreeDepth is the deepest tree that can be addressed by a uint64 chunk limit.
+	maxTreeDepth = 64
+)
+
+// zeroHashes holds the roots of all-zero subtrees, where zeroHashes[i] is the root of a tree of
+// depth i whose leaves are all zero chunks.
+var zeroHashes = func() [maxTreeDepth + 1][32]byte {
+	var ret [maxTreeDepth + 1][32]byte
+	for i := 1; i <= maxTreeDepth; i++ {
+		ret[i] = hashPair(ret[i-1], ret[i-1])
+	}
+	return ret
+}()
+
+// hashPair returns the SHA-256 hash of the concatenation of two chunks.
+func hashPair(a, b [32]byte) [32]byte {
+	var buf [2 * chunkSize]byte
+	copy(buf[:chunkSize], a[:])
+	copy(buf[chunkSize:], b[:])
+	return sha256.Sum256(buf[:])
+}
+
+// chunkCount returns the number of chunks needed to hold n bits, as defined by the SSZ spec for
+// both bitvectors and bitlists: (n + 255) / 256.
+func chunkCount(n uint64) uint64 {
+	ret := n / bitsPerChunk
+	if n%bitsPerChunk != 0 {
+		ret++
+	}
+	return ret
+}
+
+// treeDepth returns the depth of the merkle tree holding up to limit chunks i.e.
+// ceil(log2(limit)), with a tree of at most one chunk having a depth of zero.
+func treeDepth(limit uint64) uint64 {
+	if limit <= 1 {
+		return 0
+	}
+	return uint64(bits.Len64(limit - 1))
+}
+
+// packBits returns the little endian bytes of the bitfield zero padded to a whole number of
+// chunks. Only the first n bits of b are used, any bits beyond n are cleared.
+func packBits(b []byte, n uint64) []byte {
+	numBytes := (n + 7) >> 3
+	ret := make([]byte, chunkCount(n)*chunkSize)
+	copy(ret, b[:numBytes])
+	if n%8 != 0 {
+		ret[numBytes-1] &= uint8(0xff >> (8 - n%8))
+	}
+	return ret
+}
+
+// merkleize returns the SSZ merkle root of the packed chunks, which are virtually padded with
+// zero chunks up to the next power of two of limit. The chunks buffer is used as scratch space
+// and is overwritten. The number of chunks must not exceed limit.
+func merkleize(chunks []byte, limit uint64) [32]byte {
+	depth := treeDepth(limit)
+	count := len(chunks) / chunkSize
+	if count == 0 {
+		return zeroHashes[depth]
+	}
+
+	// Hash the tree layer by layer. Every pair of chunks is hashed into the slot of the left
+	// chunk's parent, which always lies at or before the pair itself, so hashing in place is safe.
+	for d := uint64(0); d < depth; d++ {
+		if count%2 == 1 {
+			chunks = append(chunks[:count*chunkSize], zeroHashes[d][:]...)
+			count++
+		}
+		for i := 0; i < count/2; i++ {
+			sum := sha256.Sum256(chunks[i*2*chunkSize : (i+1)*2*chunkSize])
+			copy(chunks[i*chunkSize:], sum[:])
+		}
+		count /= 2
+	}
+
+	var root [32]byte
+	copy(root[:], chunks[:chunkSize])
+	return root
+}
+
+// mixInLength returns the root mixed in with the length, as used for SSZ lists.
+func mixInLength(root [32]byte, length uint64) [32]byte {
+	var lengthChunk [32]byte
+	binary.LittleEndian.PutUint64(lengthChunk[:], length)
+	return hashPair(root, lengthChunk)
+}
+
+// bitvectorHashTreeRoot returns the SSZ hash tree root of a bitvector of n bits.
+func bitvectorHashTreeRoot(b []byte, n uint64) [32]byte {
+	return merkleize(packBits(b, n), chunkCount(n))
+}
+
+// bitlistHashTreeRoot returns the SSZ hash tree root of a bitlist of n bits, holding at most
+// limit bits, where b holds the bits without the length bit.
+func bitlistHashTreeRoot(b []byte, n, limit uint64) [32]byte {
+	return mixInLength(merkleize(packBits(b, n), chunkCount(limit)), n)
+}
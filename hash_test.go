@@ -0,0 +1,193 @@
+package bitfield
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBitlist_HashTreeRoot(t *testing.T) {
+	tests := []struct {
+		bitlist Bitlist
+		limit   uint64
+		want    string
+	}{
+		{
+			bitlist: Bitlist{0x01}, // Empty bitlist.
+			limit:   0,
+			want:    "f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b",
+		},
+		{
+			bitlist: Bitlist{0x01}, // Empty bitlist, padded to 8 chunks.
+			limit:   2048,
+			want:    "e8e527e84f666163a90ef900e013f56b0a4d020148b2224057b719f351b003a6",
+		},
+		{
+			bitlist: Bitlist{0x0b}, // 0b00001011, bits=[1,1,0]
+			limit:   8,
+			want:    "a8e9d684dceaef6e6a478c2130ee96a72d37aae54289bcb5972f31c027994f5f",
+		},
+		{
+			bitlist: Bitlist{0xff, 0x01}, // Length bit in a separate byte.
+			limit:   2048,
+			want:    "eebfa0d92b6c11efb3105805eae7ab6f4120ca797b958f5c7e7e5c46e2fb23be",
+		},
+		{
+			bitlist: Bitlist{0x55, 0xaa, 0x03},
+			limit:   2048,
+			want:    "520d9f224dfca996c1c303a427016b48aa13adaa4354b1f21d8f44693bdb5be0",
+		},
+		{
+			bitlist: append(bytes.Repeat([]byte{0xff}, 32), 0x01), // Exactly one full chunk.
+			limit:   256,
+			want:    "bc16fae79b58a2e3dac0429d25b79cada399106276e08c5d3cfc3726db02b8ba",
+		},
+		{
+			bitlist: append(bytes.Repeat([]byte{0xff}, 32), 0x01),
+			limit:   2048,
+			want:    "9eb31f16a445d6fa40aa3c3aa47f7d8b960299c1a5f953e9df0af00371fc1c85",
+		},
+		{
+			bitlist: append(bytes.Repeat([]byte{0x0f}, 37), 0x10), // Spans two chunks.
+			limit:   512,
+			want:    "617756861bfc60b071424a62d8fe82137f014008d7ec8c32266e58817e806166",
+		},
+		{
+			bitlist: append(bytes.Repeat([]byte{0x0f}, 37), 0x10),
+			limit:   1 << 40,
+			want:    "9b4567d2f90584eb9cd711250794210fda15fef9127fa7b574ec8763bb7974e4",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.bitlist.HashTreeRoot(tt.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got[:]) != tt.want {
+			t.Errorf("(%x).HashTreeRoot(%d) = %x, wanted %s", tt.bitlist, tt.limit, got, tt.want)
+		}
+
+		b64, err := tt.bitlist.ToBitlist64()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = b64.HashTreeRoot(tt.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got[:]) != tt.want {
+			t.Errorf("(%+v).HashTreeRoot(%d) = %x, wanted %s", b64, tt.limit, got, tt.want)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		if _, err := NewBitlist(9).HashTreeRoot(8); err != ErrBitlistExceedsLimit {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistExceedsLimit, err)
+		}
+		if _, err := NewBitlist64(9).HashTreeRoot(8); err != ErrBitlistExceedsLimit {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistExceedsLimit, err)
+		}
+	})
+}
+
+func TestBitlist64_HashTreeRoot_UnusedBits(t *testing.T) {
+	// Bits beyond the size of the bitlist must not affect the root.
+	b, err := NewBitlist64FromBytes(3, []byte{0xfb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := b.HashTreeRoot(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := Bitlist{0x0b}.HashTreeRoot(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("HashTreeRoot() = %x, wanted %x", got, want)
+	}
+}
+
+func TestBitvector_HashTreeRoot(t *testing.T) {
+	type hashTreeRooter interface {
+		HashTreeRoot() ([32]byte, error)
+	}
+	tests := []struct {
+		bitvector hashTreeRooter
+		want      string
+	}{
+		{
+			bitvector: Bitvector2{0x01},
+			want:      "0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			bitvector: Bitvector2{0xfd}, // Bits beyond the length are ignored.
+			want:      "0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			bitvector: Bitvector4{0x0a},
+			want:      "0a00000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			bitvector: Bitvector8{0xa5},
+			want:      "a500000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			bitvector: Bitvector32{0x01, 0x23, 0x45, 0x67},
+			want:      "0123456700000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			bitvector: Bitvector64{0x01, 0x23, 0xE2, 0xFE, 0xDD, 0xAC, 0xAD, 0xAD},
+			want:      "0123e2feddacadad000000000000000000000000000000000000000000000000",
+		},
+		{
+			bitvector: Bitvector128{
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+			},
+			want: "000102030405060708090a0b0c0d0e0f00000000000000000000000000000000",
+		},
+		{
+			bitvector: Bitvector256{
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+			},
+			want: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		},
+		{
+			bitvector: NewBitvector512(),
+			want:      "f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b",
+		},
+		{
+			bitvector: Bitvector512{
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+				0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c, 0x2d, 0x2e, 0x2f,
+				0x30, 0x31, 0x32, 0x33, 0x34, 0x35, 0x36, 0x37, 0x38, 0x39, 0x3a, 0x3b, 0x3c, 0x3d, 0x3e, 0x3f,
+			},
+			want: "fdeab9acf3710362bd2658cdc9a29e8f9c757fcf9811603a8c447cd1d9151108",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.bitvector.HashTreeRoot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got[:]) != tt.want {
+			t.Errorf("(%x).HashTreeRoot() = %x, wanted %s", tt.bitvector, got, tt.want)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		for _, bv := range []hashTreeRooter{
+			Bitvector2{}, Bitvector4{0x00, 0x00}, Bitvector8{}, Bitvector32{0x00}, Bitvector64{0x00},
+			Bitvector128{0x00}, Bitvector256{0x00}, Bitvector512{0x00},
+		} {
+			if _, err := bv.HashTreeRoot(); err != ErrWrongLen {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package bitfield
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// bitScanner is implemented by all bitfields supporting next and previous bit queries.
+type bitScanner interface {
+	BitAt(idx uint64) bool
+	Len() uint64
+	NextSet(from uint64) (uint64, bool)
+	NextClear(from uint64) (uint64, bool)
+	PrevSet(from uint64) (uint64, bool)
+	PrevClear(from uint64) (uint64, bool)
+}
+
+// checkNextPrev compares the next and previous bit queries from every position against a BitAt
+// scan.
+func checkNextPrev(t *testing.T, b bitScanner) {
+	t.Helper()
+	scan := func(from uint64, step int, val bool) (uint64, bool) {
+		for i := int(from); i >= 0 && i < int(b.Len()); i += step {
+			if b.BitAt(uint64(i)) == val {
+				return uint64(i), true
+			}
+		}
+		return 0, false
+	}
+	for from := uint64(0); from <= b.Len()+1; from++ {
+		last := min(int(from), int(b.Len())-1)
+		for _, q := range []struct {
+			name    string
+			fn      func(uint64) (uint64, bool)
+			start   uint64
+			step    int
+			val     bool
+			noStart bool
+		}{
+			{"NextSet", b.NextSet, from, 1, true, false},
+			{"NextClear", b.NextClear, from, 1, false, false},
+			{"PrevSet", b.PrevSet, uint64(last), -1, true, last < 0},
+			{"PrevClear", b.PrevClear, uint64(last), -1, false, last < 0},
+		} {
+			wantIdx, wantOk := uint64(0), false
+			if !q.noStart {
+				wantIdx, wantOk = scan(q.start, q.step, q.val)
+			}
+			if idx, ok := q.fn(from); idx != wantIdx || ok != wantOk {
+				t.Errorf("(%v).%s(%d) = %d, %t, wanted %d, %t", b, q.name, from, idx, ok, wantIdx, wantOk)
+			}
+		}
+	}
+}
+
+// rangeBitfield is implemented by all bitfields supporting range operations.
+type rangeBitfield interface {
+	BitAt(idx uint64) bool
+	Len() uint64
+	SetRange(from, to uint64, val bool) error
+	ClearRange(from, to uint64) error
+	FlipRange(from, to uint64) error
+	CountRange(from, to uint64) (uint64, error)
+}
+
+// checkRangeOps applies random range operations to the empty bitfield b, comparing the results
+// against the same operations done bit by bit on a slice of bools. Ranges past the end must be
+// rejected with ErrInvalidRange and leave the bitfield unchanged.
+func checkRangeOps(t *testing.T, b rangeBitfield) {
+	t.Helper()
+	r := rand.New(rand.NewSource(1))
+	want := make([]bool, b.Len())
+	for i := 0; i < 200; i++ {
+		// Pick ranges which go past the end of the bitfield every now and then.
+		from, to := uint64(r.Intn(int(b.Len())+2)), uint64(r.Intn(int(b.Len())+2))
+		if from > to && r.Intn(2) == 0 {
+			from, to = to, from
+		}
+		valid := from <= to && to <= b.Len()
+
+		var err error
+		op := r.Intn(4)
+		switch op {
+		case 0:
+			err = b.SetRange(from, to, true)
+		case 1:
+			err = b.ClearRange(from, to)
+		case 2:
+			err = b.FlipRange(from, to)
+		case 3:
+			var got uint64
+			got, err = b.CountRange(from, to)
+			if valid {
+				var cnt uint64
+				for _, val := range want[from:to] {
+					if val {
+						cnt++
+					}
+				}
+				if got != cnt {
+					t.Errorf("CountRange(%d, %d) = %d, wanted %d", from, to, got, cnt)
+				}
+			}
+		}
+		if !valid {
+			if !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("Wrong error returned for range [%d, %d). Wanted %v, got %v", from, to, ErrInvalidRange, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := from; j < to; j++ {
+			switch op {
+			case 0:
+				want[j] = true
+			case 1:
+				want[j] = false
+			case 2:
+				want[j] = !want[j]
+			}
+		}
+
+		for j, val := range want {
+			if b.BitAt(uint64(j)) != val {
+				t.Fatalf("After op %d on [%d, %d): BitAt(%d) = %t, wanted %t", op, from, to, j, !val, val)
+			}
+		}
+		if b.Len() != uint64(len(want)) {
+			t.Fatalf("Len() = %d, wanted %d", b.Len(), len(want))
+		}
+	}
+}
+
+// checkStrictAccessors sets every bit of the empty bitfield b through TrySetBitAt, reading it back
+// with TryBitAt, and checks that the first index past the end is reported as out of range.
+func checkStrictAccessors(t *testing.T, b StrictBitfield) {
+	t.Helper()
+	for idx := uint64(0); idx < b.Len(); idx++ {
+		if err := b.TrySetBitAt(idx, true); err != nil {
+			t.Fatalf("TrySetBitAt(%d) returned %v", idx, err)
+		}
+		if got, err := b.TryBitAt(idx); !got || err != nil {
+			t.Fatalf("TryBitAt(%d) = %t, %v, wanted true", idx, got, err)
+		}
+	}
+	if b.Count() != b.Len() {
+		t.Errorf("Count() = %d, wanted %d", b.Count(), b.Len())
+	}
+
+	var outOfRange *ErrIndexOutOfRange
+	if _, err := b.TryBitAt(b.Len()); !errors.As(err, &outOfRange) || outOfRange.Index != b.Len() || outOfRange.Len != b.Len() {
+		t.Errorf("TryBitAt(%d) returned %v, wanted index out of range", b.Len(), err)
+	}
+	if err := b.TrySetBitAt(b.Len()+10, true); !errors.As(err, &outOfRange) || outOfRange.Index != b.Len()+10 || outOfRange.Len != b.Len() {
+		t.Errorf("TrySetBitAt(%d) returned %v, wanted index out of range", b.Len()+10, err)
+	}
+}
@@ -0,0 +1,95 @@
+package bitfield
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PartitionMode selects the algorithm used by PartitionDisjoint.
+type PartitionMode int
+
+const (
+	// PartitionGreedy assigns every bitlist, in input order, to the first group it does not overlap.
+	PartitionGreedy PartitionMode = iota
+	// PartitionDegreeOrder colours the graph of overlapping bitlists, assigning the bitlists which
+	// overlap the most others first. It costs a comparison of every pair of bitlists, but usually
+	// yields fewer groups than PartitionGreedy.
+	PartitionDegreeOrder
+)
+
+// PartitionDisjoint groups the bitlists into sets whose members are pairwise non-overlapping, so
+// that no bit is set in more than one member of a group. It returns the indices of the bitlists in
+// every group in ascending order, and groups are ordered by their lowest member. Both modes are
+// deterministic. This function will return an error if the bitlists are not the same length, or
+// ErrUnknownPartitionMode if mode is not a known PartitionMode.
+func PartitionDisjoint(bitlists []*Bitlist64, mode PartitionMode) ([][]int, error) {
+	for i, b := range bitlists {
+		if b.Len() != bitlists[0].Len() {
+			return nil, fmt.Errorf("%w: bitlists[%d] has length %d, wanted %d", ErrBitlistDifferentLength, i, b.Len(), bitlists[0].Len())
+		}
+	}
+
+	order := make([]int, len(bitlists))
+	for i := range order {
+		order[i] = i
+	}
+	switch mode {
+	case PartitionGreedy:
+	case PartitionDegreeOrder:
+		degrees := make([]int, len(bitlists))
+		for i := range bitlists {
+			for j := i + 1; j < len(bitlists); j++ {
+				if !disjoint(bitlists[i], bitlists[j]) {
+					degrees[i]++
+					degrees[j]++
+				}
+			}
+		}
+		// The sort is stable, so ties keep the input order.
+		slices.SortStableFunc(order, func(a, b int) int {
+			return degrees[b] - degrees[a]
+		})
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPartitionMode, mode)
+	}
+
+	// A bitlist overlaps a member of a group if and only if it overlaps the union of the group.
+	var groups [][]int
+	var unions []*Bitlist64
+	for _, i := range order {
+		placed := false
+		for g, union := range unions {
+			if disjoint(bitlists[i], union) {
+				groups[g] = append(groups[g], i)
+				if err := union.OrInPlace(bitlists[i]); err != nil {
+					return nil, err
+				}
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			groups = append(groups, []int{i})
+			unions = append(unions, bitlists[i].Clone())
+		}
+	}
+
+	for _, group := range groups {
+		slices.Sort(group)
+	}
+	slices.SortFunc(groups, func(a, b []int) int {
+		return a[0] - b[0]
+	})
+	return groups, nil
+}
+
+// disjoint returns true if none of the bits set in a is set in b. Unused bits of a past its size
+// are ignored, so that they cannot cause a false conflict.
+func disjoint(a, b *Bitlist64) bool {
+	for idx := range a.data {
+		if a.maskedWord(uint64(idx), false)&b.data[idx] != 0 {
+			return false
+		}
+	}
+	return true
+}
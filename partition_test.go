@@ -0,0 +1,107 @@
+package bitfield
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestPartitionDisjoint(t *testing.T) {
+	// Overlap graph is the path 0-1-3-2-4, each edge being a shared bit, and bitlist 5 overlaps
+	// nothing. Taken in input order, bitlist 3 overlaps both groups formed by 0, 1 and 2.
+	bitlists := []*Bitlist64{
+		NewBitlist64From([]uint64{0x01}), // Edge 0-1.
+		NewBitlist64From([]uint64{0x03}), // Edges 0-1, 1-3.
+		NewBitlist64From([]uint64{0x0c}), // Edges 3-2, 2-4.
+		NewBitlist64From([]uint64{0x06}), // Edges 1-3, 3-2.
+		NewBitlist64From([]uint64{0x08}), // Edge 2-4.
+		NewBitlist64From([]uint64{0x10}), // No edges.
+	}
+
+	tests := []struct {
+		mode PartitionMode
+		want [][]int
+	}{
+		{
+			mode: PartitionGreedy,
+			want: [][]int{{0, 2, 5}, {1, 4}, {3}},
+		},
+		{
+			mode: PartitionDegreeOrder,
+			want: [][]int{{0, 3, 4}, {1, 2, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := PartitionDisjoint(bitlists, tt.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PartitionDisjoint(%d) = %v, wanted %v", tt.mode, got, tt.want)
+		}
+	}
+
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		bitlists := make([]*Bitlist64, 50)
+		for i := range bitlists {
+			bitlists[i] = NewBitlist64(100)
+			for j := 0; j < 5; j++ {
+				bitlists[i].SetBitAt(uint64(r.Intn(100)), true)
+			}
+		}
+		for _, mode := range []PartitionMode{PartitionGreedy, PartitionDegreeOrder} {
+			groups, err := PartitionDisjoint(bitlists, mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen := make(map[int]bool)
+			for _, group := range groups {
+				for x, i := range group {
+					if seen[i] {
+						t.Errorf("PartitionDisjoint(%d) placed bitlist %d twice", mode, i)
+					}
+					seen[i] = true
+					for _, j := range group[x+1:] {
+						if overlaps, _ := bitlists[i].Overlaps(bitlists[j]); overlaps {
+							t.Errorf("PartitionDisjoint(%d) grouped overlapping bitlists %d and %d", mode, i, j)
+						}
+					}
+				}
+			}
+			if len(seen) != len(bitlists) {
+				t.Errorf("PartitionDisjoint(%d) placed %d bitlists, wanted %d", mode, len(seen), len(bitlists))
+			}
+		}
+	})
+
+	t.Run("no bitlists", func(t *testing.T) {
+		if got, err := PartitionDisjoint(nil, PartitionDegreeOrder); len(got) != 0 || err != nil {
+			t.Errorf("PartitionDisjoint(nil) = %v, %v, wanted empty", got, err)
+		}
+	})
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Both bitlists have bit 3 set past their size of 3 bits, which is not a conflict.
+		dirty := []*Bitlist64{{size: 3, data: []uint64{0xf9}}, {size: 3, data: []uint64{0x0a}}}
+		for _, mode := range []PartitionMode{PartitionGreedy, PartitionDegreeOrder} {
+			if got, err := PartitionDisjoint(dirty, mode); err != nil || !reflect.DeepEqual(got, [][]int{{0, 1}}) {
+				t.Errorf("PartitionDisjoint(%d) = %v, %v, wanted [[0 1]]", mode, got, err)
+			}
+		}
+	})
+
+	t.Run("check errors", func(t *testing.T) {
+		mixed := []*Bitlist64{NewBitlist64(64), NewBitlist64(128)}
+		for _, mode := range []PartitionMode{PartitionGreedy, PartitionDegreeOrder} {
+			if _, err := PartitionDisjoint(mixed, mode); !errors.Is(err, ErrBitlistDifferentLength) {
+				t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+			}
+		}
+		if _, err := PartitionDisjoint(bitlists, PartitionMode(42)); !errors.Is(err, ErrUnknownPartitionMode) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrUnknownPartitionMode, err)
+		}
+	})
+}
@@ -0,0 +1,320 @@
+package bitfield
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// lengthGeneralizedIndex is the generalized index of the length chunk mixed into a bitlist root.
+const lengthGeneralizedIndex = 3
+
+// Multiproof is a merkle multiproof that a set of bits is set in a bitfield, as described by the
+// SSZ merkle proof formats. The proof is checked against a hash tree root with
+// VerifyBitlistMultiproof or VerifyBitvectorMultiproof.
+type Multiproof struct {
+	// Indices are the generalized indices of the proven leaves, in ascending order.
+	Indices []uint64
+	// Leaves are the chunks at the generalized indices. For bitlists, this includes the length
+	// chunk, so that the verifier can check the proven bits are within the bitlist.
+	Leaves [][32]byte
+	// Hashes are the sibling hashes required to recompute the root, ordered by descending
+	// generalized index.
+	Hashes [][32]byte
+}
+
+// bitvectorMultiproof builds a multiproof for the given bits of a bitvector of n bits.
+func bitvectorMultiproof(b []byte, n uint64, bitIndices []uint64) (*Multiproof, error) {
+	if err := checkProvenBits(b, n, bitIndices); err != nil {
+		return nil, err
+	}
+
+	depth := treeDepth(chunkCount(n))
+	layers := merkleLayers(packBits(b, n), depth)
+	indices := chunkGeneralizedIndices(bitIndices, depth)
+
+	return buildMultiproof(indices, func(gIndex uint64) [32]byte {
+		return treeNode(layers, gIndex)
+	}), nil
+}
+
+// bitlistMultiproof builds a multiproof for the given bits of a bitlist of n bits, holding at most
+// limit bits, where b holds the bits without the length bit.
+func bitlistMultiproof(b []byte, n, limit uint64, bitIndices []uint64) (*Multiproof, error) {
+	if n > limit {
+		return nil, ErrBitlistExceedsLimit
+	}
+	if err := checkProvenBits(b, n, bitIndices); err != nil {
+		return nil, err
+	}
+
+	// The data tree hangs off the left of the root, with the length chunk on the right.
+	depth := treeDepth(chunkCount(limit)) + 1
+	layers := merkleLayers(packBits(b, n), depth-1)
+	indices := append([]uint64{lengthGeneralizedIndex}, chunkGeneralizedIndices(bitIndices, depth)...)
+
+	var lengthChunk [32]byte
+	binary.LittleEndian.PutUint64(lengthChunk[:], n)
+
+	return buildMultiproof(indices, func(gIndex uint64) [32]byte {
+		if gIndex == lengthGeneralizedIndex {
+			return lengthChunk
+		}
+		// Strip the leading left turn to address the node within the data tree.
+		d := generalizedIndexDepth(gIndex)
+		return treeNode(layers, gIndex^(1<<d)|(1<<(d-1)))
+	}), nil
+}
+
+// VerifyBitvectorMultiproof checks that the proof shows the bits at the given indices are set in a
+// bitvector of n bits with the given hash tree root.
+func VerifyBitvectorMultiproof(root [32]byte, n uint64, bitIndices []uint64, proof *Multiproof) error {
+	if len(bitIndices) == 0 {
+		return fmt.Errorf("%w: no bit indices", ErrInvalidProof)
+	}
+	for _, idx := range bitIndices {
+		if idx >= n {
+			return fmt.Errorf("%w: bit index %d is out of range for %d bits", ErrInvalidProof, idx, n)
+		}
+	}
+
+	depth := treeDepth(chunkCount(n))
+	return verifyMultiproof(root, chunkGeneralizedIndices(bitIndices, depth), depth, bitIndices, proof)
+}
+
+// VerifyBitlistMultiproof checks that the proof shows the bits at the given indices are set in a
+// bitlist holding at most limit bits with the given hash tree root.
+func VerifyBitlistMultiproof(root [32]byte, limit uint64, bitIndices []uint64, proof *Multiproof) error {
+	if len(bitIndices) == 0 {
+		return fmt.Errorf("%w: no bit indices", ErrInvalidProof)
+	}
+	if proof == nil {
+		return fmt.Errorf("%w: no proof", ErrInvalidProof)
+	}
+	if len(proof.Leaves) == 0 || len(proof.Indices) == 0 || proof.Indices[0] != lengthGeneralizedIndex {
+		return fmt.Errorf("%w: missing length leaf", ErrInvalidProof)
+	}
+
+	// The length is a little endian uint256, of which only the lower 64 bits can be used.
+	lengthChunk := proof.Leaves[0]
+	for _, bt := range lengthChunk[8:] {
+		if bt != 0 {
+			return fmt.Errorf("%w: length exceeds limit", ErrInvalidProof)
+		}
+	}
+	n := binary.LittleEndian.Uint64(lengthChunk[:8])
+	if n > limit {
+		return fmt.Errorf("%w: length %d exceeds limit %d", ErrInvalidProof, n, limit)
+	}
+	for _, idx := range bitIndices {
+		if idx >= n {
+			return fmt.Errorf("%w: bit index %d is out of range for %d bits", ErrInvalidProof, idx, n)
+		}
+	}
+
+	depth := treeDepth(chunkCount(limit)) + 1
+	indices := append([]uint64{lengthGeneralizedIndex}, chunkGeneralizedIndices(bitIndices, depth)...)
+	return verifyMultiproof(root, indices, depth, bitIndices, proof)
+}
+
+// verifyMultiproof checks the proof has the expected leaves, that every proven bit is set in its
+// chunk, and that the leaves and hashes combine into the root.
+func verifyMultiproof(root [32]byte, indices []uint64, depth uint64, bitIndices []uint64, proof *Multiproof) error {
+	if proof == nil {
+		return fmt.Errorf("%w: no proof", ErrInvalidProof)
+	}
+	if len(proof.Indices) != len(indices) || len(proof.Leaves) != len(indices) {
+		return fmt.Errorf("%w: wrong number of leaves", ErrInvalidProof)
+	}
+	leaves := make(map[uint64][32]byte, len(indices))
+	for i, gIndex := range indices {
+		if proof.Indices[i] != gIndex {
+			return fmt.Errorf("%w: unexpected generalized index %d", ErrInvalidProof, proof.Indices[i])
+		}
+		leaves[gIndex] = proof.Leaves[i]
+	}
+
+	for _, idx := range bitIndices {
+		chunk := leaves[uint64(1)<<depth+idx/bitsPerChunk]
+		bit := idx % bitsPerChunk
+		if chunk[bit>>3]&(1<<(bit%8)) == 0 {
+			return fmt.Errorf("%w: bit %d", ErrBitNotSet, idx)
+		}
+	}
+
+	got, err := calculateMultiRoot(indices, proof.Leaves, proof.Hashes)
+	if err != nil {
+		return err
+	}
+	if got != root {
+		return fmt.Errorf("%w: root mismatch", ErrInvalidProof)
+	}
+	return nil
+}
+
+// checkProvenBits ensures every bit to be proven is within the bitfield and set. It returns
+// ErrNoBitIndices if there is nothing to prove, an *ErrIndexOutOfRange if a bit is out of range,
+// and ErrBitNotSet if a bit is not set.
+func checkProvenBits(b []byte, n uint64, bitIndices []uint64) error {
+	if len(bitIndices) == 0 {
+		return ErrNoBitIndices
+	}
+	for _, idx := range bitIndices {
+		if idx >= n {
+			return &ErrIndexOutOfRange{Index: idx, Len: n}
+		}
+		if b[idx>>3]&(1<<(idx%8)) == 0 {
+			return fmt.Errorf("%w: bit %d", ErrBitNotSet, idx)
+		}
+	}
+	return nil
+}
+
+// chunkGeneralizedIndices returns the sorted, deduplicated generalized indices of the chunks
+// holding the given bits, in a tree where chunks are found at the given depth.
+func chunkGeneralizedIndices(bitIndices []uint64, depth uint64) []uint64 {
+	indices := make([]uint64, 0, len(bitIndices))
+	for _, idx := range bitIndices {
+		indices = append(indices, uint64(1)<<depth+idx/bitsPerChunk)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	// Several bits may share a chunk, keep only the unique indices.
+	ret := indices[:0]
+	for _, gIndex := range indices {
+		if len(ret) == 0 || gIndex != ret[len(ret)-1] {
+			ret = append(ret, gIndex)
+		}
+	}
+	return ret
+}
+
+// buildMultiproof assembles the proof for the given sorted generalized indices, fetching tree
+// nodes with the provided function.
+func buildMultiproof(indices []uint64, node func(gIndex uint64) [32]byte) *Multiproof {
+	helpers := helperIndices(indices)
+	proof := &Multiproof{
+		Indices: indices,
+		Leaves:  make([][32]byte, len(indices)),
+		Hashes:  make([][32]byte, len(helpers)),
+	}
+	for i, gIndex := range indices {
+		proof.Leaves[i] = node(gIndex)
+	}
+	for i, gIndex := range helpers {
+		proof.Hashes[i] = node(gIndex)
+	}
+	return proof
+}
+
+// helperIndices returns the generalized indices of all the sibling nodes needed to prove the
+// given indices, excluding any nodes that can be computed from the indices themselves. Indices are
+// returned in descending order.
+func helperIndices(indices []uint64) []uint64 {
+	branch := make(map[uint64]bool)
+	path := make(map[uint64]bool)
+	for _, gIndex := range indices {
+		for g := gIndex; g > 1; g >>= 1 {
+			branch[g^1] = true
+			path[g] = true
+		}
+	}
+
+	ret := make([]uint64, 0, len(branch))
+	for g := range branch {
+		if !path[g] {
+			ret = append(ret, g)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] > ret[j] })
+	return ret
+}
+
+// calculateMultiRoot recomputes the root from the leaves at the given generalized indices and the
+// helper hashes of the proof.
+func calculateMultiRoot(indices []uint64, leaves, hashes [][32]byte) ([32]byte, error) {
+	helpers := helperIndices(indices)
+	if len(hashes) != len(helpers) {
+		return [32]byte{}, fmt.Errorf("%w: wrong number of hashes", ErrInvalidProof)
+	}
+
+	nodes := make(map[uint64][32]byte, len(indices)+len(helpers))
+	keys := make([]uint64, 0, 2*(len(indices)+len(helpers)))
+	for i, gIndex := range indices {
+		nodes[gIndex] = leaves[i]
+		keys = append(keys, gIndex)
+	}
+	for i, gIndex := range helpers {
+		nodes[gIndex] = hashes[i]
+		keys = append(keys, gIndex)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] > keys[j] })
+
+	// Walk the nodes from the deepest up, hashing every pair of known siblings into their parent.
+	for pos := 0; pos < len(keys); pos++ {
+		g := keys[pos]
+		if g <= 1 {
+			continue
+		}
+		if _, ok := nodes[g>>1]; ok {
+			continue
+		}
+		left, okLeft := nodes[g&^1]
+		right, okRight := nodes[g|1]
+		if okLeft && okRight {
+			nodes[g>>1] = hashPair(left, right)
+			keys = append(keys, g>>1)
+		}
+	}
+
+	root, ok := nodes[1]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("%w: incomplete proof", ErrInvalidProof)
+	}
+	return root, nil
+}
+
+// merkleLayers returns every layer of the merkle tree of the given depth over the chunks, starting
+// with the chunks themselves and ending with the root. Layers are not padded, any missing node is
+// the root of an all-zero subtree.
+func merkleLayers(chunks []byte, depth uint64) [][][32]byte {
+	layer := make([][32]byte, len(chunks)/chunkSize)
+	for i := range layer {
+		copy(layer[i][:], chunks[i*chunkSize:])
+	}
+
+	layers := make([][][32]byte, 0, depth+1)
+	layers = append(layers, layer)
+	for d := uint64(0); d < depth; d++ {
+		parent := make([][32]byte, (len(layer)+1)/2)
+		for i := range parent {
+			right := zeroHashes[d]
+			if 2*i+1 < len(layer) {
+				right = layer[2*i+1]
+			}
+			parent[i] = hashPair(layer[2*i], right)
+		}
+		layer = parent
+		layers = append(layers, layer)
+	}
+	return layers
+}
+
+// treeNode returns the node at the generalized index of the tree described by the layers.
+func treeNode(layers [][][32]byte, gIndex uint64) [32]byte {
+	depth := uint64(len(layers) - 1)
+	d := generalizedIndexDepth(gIndex)
+	layer := layers[depth-d]
+	pos := gIndex ^ (1 << d)
+	if pos < uint64(len(layer)) {
+		return layer[pos]
+	}
+	return zeroHashes[depth-d]
+}
+
+// generalizedIndexDepth returns the depth of the node at the generalized index, the root being at
+// depth zero.
+func generalizedIndexDepth(gIndex uint64) uint64 {
+	return uint64(bits.Len64(gIndex) - 1)
+}
@@ -0,0 +1,212 @@
+package bitfield
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBitvector512_Multiproof(t *testing.T) {
+	bv := NewBitvector512()
+	for _, idx := range []uint64{0, 3, 255, 256, 300, 511} {
+		bv.SetBitAt(idx, true)
+	}
+	root, err := bv.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		bitIndices  []uint64
+		wantIndices []uint64
+		wantHashes  int
+	}{
+		{
+			bitIndices:  []uint64{300},
+			wantIndices: []uint64{3},
+			wantHashes:  1,
+		},
+		{
+			bitIndices:  []uint64{0, 3, 255},
+			wantIndices: []uint64{2},
+			wantHashes:  1,
+		},
+		{
+			bitIndices:  []uint64{511, 0},
+			wantIndices: []uint64{2, 3},
+			wantHashes:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		proof, err := bv.Multiproof(tt.bitIndices)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(proof.Indices, tt.wantIndices) {
+			t.Errorf("Multiproof(%v).Indices = %v, wanted %v", tt.bitIndices, proof.Indices, tt.wantIndices)
+		}
+		if len(proof.Hashes) != tt.wantHashes {
+			t.Errorf("Multiproof(%v) has %d hashes, wanted %d", tt.bitIndices, len(proof.Hashes), tt.wantHashes)
+		}
+		if err := VerifyBitvectorMultiproof(root, 512, tt.bitIndices, proof); err != nil {
+			t.Errorf("VerifyBitvectorMultiproof(%v) = %v, wanted nil", tt.bitIndices, err)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		if _, err := bv.Multiproof([]uint64{1}); !errors.Is(err, ErrBitNotSet) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitNotSet, err)
+		}
+		var outOfRange *ErrIndexOutOfRange
+		if _, err := bv.Multiproof([]uint64{512}); !errors.As(err, &outOfRange) || outOfRange.Index != 512 {
+			t.Errorf("Wrong error returned. Wanted index 512 out of range, got %v", err)
+		}
+		if _, err := bv.Multiproof(nil); err != ErrNoBitIndices {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrNoBitIndices, err)
+		}
+		if err := VerifyBitvectorMultiproof(root, 512, []uint64{300}, nil); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidProof, err)
+		}
+		if _, err := (Bitvector512{0x01}).Multiproof([]uint64{0}); err != ErrWrongLen {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrWrongLen, err)
+		}
+
+		proof, err := bv.Multiproof([]uint64{300})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Bit 301 is in the proven chunk, but it is not set.
+		if err := VerifyBitvectorMultiproof(root, 512, []uint64{301}, proof); !errors.Is(err, ErrBitNotSet) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitNotSet, err)
+		}
+		// Claiming bit 301 is set by tampering with the leaf must break the root.
+		proof.Leaves[0][301/8-32] |= 1 << (301 % 8)
+		if err := VerifyBitvectorMultiproof(root, 512, []uint64{301}, proof); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidProof, err)
+		}
+		// A proof for one chunk does not prove bits in another chunk.
+		if err := VerifyBitvectorMultiproof(root, 512, []uint64{0}, proof); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidProof, err)
+		}
+	})
+}
+
+func TestBitvector_Multiproof(t *testing.T) {
+	type prover interface {
+		SetBitAt(idx uint64, val bool)
+		Len() uint64
+		HashTreeRoot() ([32]byte, error)
+		Multiproof(bitIndices []uint64) (*Multiproof, error)
+	}
+	for _, bv := range []prover{
+		NewBitvector2(), NewBitvector4(), NewBitvector8(), NewBitvector32(), NewBitvector64(),
+		NewBitvector128(), NewBitvector256(), NewBitvector512(),
+	} {
+		idx := bv.Len() - 1
+		bv.SetBitAt(idx, true)
+		root, err := bv.HashTreeRoot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := bv.Multiproof([]uint64{idx})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyBitvectorMultiproof(root, bv.Len(), []uint64{idx}, proof); err != nil {
+			t.Errorf("VerifyBitvectorMultiproof(%d bits) = %v, wanted nil", bv.Len(), err)
+		}
+	}
+}
+
+func TestBitlist_Multiproof(t *testing.T) {
+	const limit = 2048
+	b := NewBitlist(300)
+	for _, idx := range []uint64{0, 7, 256, 299} {
+		b.SetBitAt(idx, true)
+	}
+	b64, err := b.ToBitlist64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := b.HashTreeRoot(limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		bitIndices  []uint64
+		wantIndices []uint64
+		wantHashes  int
+	}{
+		{
+			// Length leaf, then the first of 8 chunks in a tree of depth 3 under the data root.
+			bitIndices:  []uint64{0},
+			wantIndices: []uint64{3, 16},
+			wantHashes:  3,
+		},
+		{
+			bitIndices:  []uint64{299, 7, 0},
+			wantIndices: []uint64{3, 16, 17},
+			wantHashes:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		proof, err := b.Multiproof(limit, tt.bitIndices)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(proof.Indices, tt.wantIndices) {
+			t.Errorf("Multiproof(%v).Indices = %v, wanted %v", tt.bitIndices, proof.Indices, tt.wantIndices)
+		}
+		if len(proof.Hashes) != tt.wantHashes {
+			t.Errorf("Multiproof(%v) has %d hashes, wanted %d", tt.bitIndices, len(proof.Hashes), tt.wantHashes)
+		}
+		if err := VerifyBitlistMultiproof(root, limit, tt.bitIndices, proof); err != nil {
+			t.Errorf("VerifyBitlistMultiproof(%v) = %v, wanted nil", tt.bitIndices, err)
+		}
+
+		proof64, err := b64.Multiproof(limit, tt.bitIndices)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(proof, proof64) {
+			t.Errorf("Bitlist64 Multiproof(%v) = %+v, wanted %+v", tt.bitIndices, proof64, proof)
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		if _, err := b.Multiproof(limit, []uint64{1}); !errors.Is(err, ErrBitNotSet) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitNotSet, err)
+		}
+		var outOfRange *ErrIndexOutOfRange
+		if _, err := b.Multiproof(limit, []uint64{300}); !errors.As(err, &outOfRange) || outOfRange.Index != 300 {
+			t.Errorf("Wrong error returned. Wanted index 300 out of range, got %v", err)
+		}
+		if err := VerifyBitlistMultiproof(root, limit, []uint64{256}, nil); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidProof, err)
+		}
+		if _, err := b.Multiproof(256, []uint64{0}); err != ErrBitlistExceedsLimit {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistExceedsLimit, err)
+		}
+
+		proof, err := b.Multiproof(limit, []uint64{256})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyBitlistMultiproof(root, 4096, []uint64{256}, proof); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidProof, err)
+		}
+		// Claiming a longer bitlist by tampering with the length leaf must break the root.
+		proof.Leaves[0][0]++
+		if err := VerifyBitlistMultiproof(root, limit, []uint64{256}, proof); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidProof, err)
+		}
+		proof.Leaves[0][0]--
+		proof.Hashes[0][0] ^= 0xff
+		if err := VerifyBitlistMultiproof(root, limit, []uint64{256}, proof); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrInvalidProof, err)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package bitfield
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	// wordsPerBlock is the number of words covered by a single rank sample of RankSelectIndex.
+	wordsPerBlock = 8
+	// wordsPerBlockLog2 allows optimized division by wordsPerBlock using right shift.
+	wordsPerBlockLog2 = 3
+)
+
+// RankSelectIndex is a precomputed index over a Bitlist64 answering Rank and Select queries in
+// sub-linear time. It stores the number of bits set before every block of 512 bits, so Rank scans
+// at most one block and Select binary searches the blocks before scanning one.
+//
+// The index refers to the data of the bitlist it was built from, and must be rebuilt with
+// NewRankSelectIndex after the bitlist is modified.
+type RankSelectIndex struct {
+	size   uint64
+	data   []uint64
+	blocks []uint64
+}
+
+// NewRankSelectIndex builds a rank and select index over the provided bitlist.
+func NewRankSelectIndex(b *Bitlist64) *RankSelectIndex {
+	numBlocks := (len(b.data) + wordsPerBlock - 1) >> wordsPerBlockLog2
+	blocks := make([]uint64, numBlocks+1)
+	for i := 0; i < numBlocks; i++ {
+		c := uint64(0)
+		for wordIdx := i << wordsPerBlockLog2; wordIdx < min(len(b.data), (i+1)<<wordsPerBlockLog2); wordIdx++ {
+			c += uint64(bits.OnesCount64(b.maskedWord(uint64(wordIdx), false)))
+		}
+		blocks[i+1] = blocks[i] + c
+	}
+	return &RankSelectIndex{
+		size:   b.size,
+		data:   b.data,
+		blocks: blocks,
+	}
+}
+
+// Len returns the number of bits in the indexed bitlist.
+func (r *RankSelectIndex) Len() uint64 {
+	return r.size
+}
+
+// Count returns the number of 1s in the indexed bitlist.
+func (r *RankSelectIndex) Count() uint64 {
+	return r.blocks[len(r.blocks)-1]
+}
+
+// Rank returns the number of bits set to 1 at indices lower than idx. If idx exceeds the length of
+// the bitlist, the number of all bits set is returned.
+func (r *RankSelectIndex) Rank(idx uint64) uint64 {
+	if idx >= r.size {
+		return r.Count()
+	}
+	wordIdx := idx >> wordSizeLog2
+	block := wordIdx >> wordsPerBlockLog2
+	c := r.blocks[block]
+	for _, word := range r.data[block<<wordsPerBlockLog2 : wordIdx] {
+		c += uint64(bits.OnesCount64(word))
+	}
+	if idx%wordSize != 0 {
+		c += uint64(bits.OnesCount64(r.data[wordIdx] & (allBitsSet >> (wordSize - idx%wordSize))))
+	}
+	return c
+}
+
+// Select returns the index of the k-th (counting from zero) bit set to 1, so that Rank of the
+// returned index is k. The second return value is false if fewer than k+1 bits are set.
+func (r *RankSelectIndex) Select(k uint64) (uint64, bool) {
+	if k >= r.Count() {
+		return 0, false
+	}
+	// Find the last block which starts with at most k bits set before it.
+	block := sort.Search(len(r.blocks), func(i int) bool {
+		return r.blocks[i] > k
+	}) - 1
+	k -= r.blocks[block]
+	for wordIdx := block << wordsPerBlockLog2; wordIdx < len(r.data); wordIdx++ {
+		word := r.word(wordIdx)
+		c := uint64(bits.OnesCount64(word))
+		if k < c {
+			return uint64(wordIdx)<<wordSizeLog2 + uint64(selectInWord(word, int(k))), true
+		}
+		k -= c
+	}
+	return 0, false
+}
+
+// word returns the word of the indexed bitlist at idx, with unused bits cleared.
+func (r *RankSelectIndex) word(idx int) uint64 {
+	word := r.data[idx]
+	if idx == len(r.data)-1 && r.size%wordSize != 0 {
+		word &= allBitsSet >> (wordSize - r.size%wordSize)
+	}
+	return word
+}
@@ -0,0 +1,57 @@
+package bitfield
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRankSelectIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, size := range []uint64{0, 1, 63, 64, 65, 511, 512, 513, 1000, 4096, 5000} {
+		for _, density := range []float64{0, 0.01, 0.5, 1} {
+			b := NewBitlist64(size)
+			for i := uint64(0); i < size; i++ {
+				if r.Float64() < density {
+					b.SetBitAt(i, true)
+				}
+			}
+			idx := NewRankSelectIndex(b)
+			if idx.Len() != b.Len() || idx.Count() != b.Count() {
+				t.Fatalf("NewRankSelectIndex(%d bits) Len, Count = %d, %d, wanted %d, %d",
+					size, idx.Len(), idx.Count(), b.Len(), b.Count())
+			}
+			for i := uint64(0); i <= size+1; i++ {
+				if got, want := idx.Rank(i), b.Rank(i); got != want {
+					t.Errorf("Rank(%d) over %d bits = %d, wanted %d", i, size, got, want)
+				}
+			}
+			for k := uint64(0); k <= b.Count(); k++ {
+				got, ok := idx.Select(k)
+				want, wantOk := b.Select(k)
+				if got != want || ok != wantOk {
+					t.Errorf("Select(%d) over %d bits = %d, %t, wanted %d, %t", k, size, got, ok, want, wantOk)
+				}
+				if ok && idx.Rank(got) != k {
+					t.Errorf("Rank(Select(%d)) over %d bits = %d, wanted %d", k, size, idx.Rank(got), k)
+				}
+			}
+		}
+	}
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size are not counted.
+		b := &Bitlist64{size: 3, data: []uint64{0xfb}}
+		idx := NewRankSelectIndex(b)
+		if idx.Count() != 2 || idx.Rank(3) != 2 {
+			t.Errorf("Count, Rank(3) = %d, %d, wanted 2, 2", idx.Count(), idx.Rank(3))
+		}
+		for k, want := range []uint64{0, 1} {
+			if got, ok := idx.Select(uint64(k)); !ok || got != want {
+				t.Errorf("Select(%d) = %d, %t, wanted %d, true", k, got, ok, want)
+			}
+		}
+		if got, ok := idx.Select(2); ok {
+			t.Errorf("Select(2) = %d, true, wanted false", got)
+		}
+	})
+}
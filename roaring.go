@@ -0,0 +1,534 @@
+package bitfield
+
+import (
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+var _ = StrictBitfield(&RoaringBitlist{})
+
+const (
+	// chunkBitsLog2 is log_2 of the number of bits held by a single container.
+	chunkBitsLog2 = 16
+	// chunkBits is the number of bits held by a single container.
+	chunkBits = uint64(1 << chunkBitsLog2)
+	// chunkWords is the number of words in a bitmap container.
+	chunkWords = int(chunkBits / wordSize)
+	// arrayMaxCount is the largest number of bits set held in an array container. Past it, a
+	// bitmap container is smaller.
+	arrayMaxCount = 4096
+)
+
+// RoaringBitlist is a compressed bitfield implementation, following the roaring bitmap layout. The
+// bits are split into chunks of 65536 bits, and every chunk with a bit set is held in the smallest
+// of three containers: a sorted array of the indices set, a plain bitmap, or a list of runs of
+// consecutive set bits. Bitlists which are nearly all zeros or nearly all ones take a few bytes
+// per chunk.
+//
+// Set operations work chunk by chunk, either between two RoaringBitlists or with a dense Bitlist64
+// operand, without decompressing the whole bitlist. A Bitlist operand can be converted with
+// ToBitlist64 first.
+type RoaringBitlist struct {
+	size uint64
+	// keys holds the indices of the chunks with a bit set, in ascending order.
+	keys []uint64
+	// containers holds the bits of the chunk at the same position in keys.
+	containers []container
+}
+
+// container holds the bits set in a chunk. Containers are never empty.
+type container interface {
+	// contains returns true if the bit at the given index of the chunk is 1.
+	contains(x uint16) bool
+	// count returns the number of 1s in the chunk.
+	count() int
+	// words returns the chunk as a bitmap, which may be written into buf. The returned bitmap
+	// must not be modified.
+	words(buf *[chunkWords]uint64) *[chunkWords]uint64
+	// set sets the bit at the given index of the chunk, returning the container which holds the
+	// result. That is nil if no bit is left set.
+	set(x uint16, val bool) container
+}
+
+// zeroChunk is a bitmap with no bit set.
+var zeroChunk [chunkWords]uint64
+
+// NewRoaringBitlist creates a new compressed bitlist of size `n`, with all bits set to 0.
+func NewRoaringBitlist(n uint64) *RoaringBitlist {
+	return &RoaringBitlist{size: n}
+}
+
+// NewRoaringBitlistFromBitlist64 creates a new compressed bitlist holding the same bits as b.
+func NewRoaringBitlistFromBitlist64(b *Bitlist64) *RoaringBitlist {
+	r := NewRoaringBitlist(b.Len())
+	var buf [chunkWords]uint64
+	for k := uint64(0); k < r.numChunks(); k++ {
+		if c := newContainer(b.chunk(k, &buf)); c != nil {
+			r.keys = append(r.keys, k)
+			r.containers = append(r.containers, c)
+		}
+	}
+	return r
+}
+
+// NewRoaringBitlistFromBitlist creates a new compressed bitlist holding the same bits as b.
+func NewRoaringBitlistFromBitlist(b Bitlist) (*RoaringBitlist, error) {
+	b64, err := b.ToBitlist64()
+	if err != nil {
+		return nil, err
+	}
+	return NewRoaringBitlistFromBitlist64(b64), nil
+}
+
+// ToBitlist64 converts the compressed bitlist into []uint64 backed bitlist.
+func (r *RoaringBitlist) ToBitlist64() *Bitlist64 {
+	b := NewBitlist64(r.size)
+	var buf [chunkWords]uint64
+	for i, k := range r.keys {
+		copy(b.data[k*uint64(chunkWords):], r.containers[i].words(&buf)[:])
+	}
+	return b
+}
+
+// ToBitlist converts the compressed bitlist into []byte backed bitlist.
+func (r *RoaringBitlist) ToBitlist() Bitlist {
+	return r.ToBitlist64().ToBitlist()
+}
+
+// BitAt returns the bit value at the given index. If the index requested
+// exceeds the number of bits in the bitlist, then this method returns false.
+func (r *RoaringBitlist) BitAt(idx uint64) bool {
+	// Out of bounds, must be false.
+	if idx >= r.size {
+		return false
+	}
+
+	i, ok := slices.BinarySearch(r.keys, idx>>chunkBitsLog2)
+	return ok && r.containers[i].contains(uint16(idx))
+}
+
+// SetBitAt will set the bit at the given index to the given value. If the index
+// requested exceeds the number of bits in the bitlist, then this method does nothing.
+func (r *RoaringBitlist) SetBitAt(idx uint64, val bool) {
+	// Out of bounds, do nothing.
+	if idx >= r.size {
+		return
+	}
+
+	i, ok := slices.BinarySearch(r.keys, idx>>chunkBitsLog2)
+	switch {
+	case ok:
+		if c := r.containers[i].set(uint16(idx), val); c != nil {
+			r.containers[i] = c
+		} else {
+			r.keys = slices.Delete(r.keys, i, i+1)
+			r.containers = slices.Delete(r.containers, i, i+1)
+		}
+	case val:
+		r.keys = slices.Insert(r.keys, i, idx>>chunkBitsLog2)
+		r.containers = slices.Insert(r.containers, i, container(arrayContainer{uint16(idx)}))
+	}
+}
+
+// TryBitAt returns the bit value at the given index. Unlike BitAt, it returns an
+// *ErrIndexOutOfRange if the index exceeds the number of bits in the bitlist.
+func (r *RoaringBitlist) TryBitAt(idx uint64) (bool, error) {
+	if idx >= r.size {
+		return false, &ErrIndexOutOfRange{Index: idx, Len: r.size}
+	}
+	return r.BitAt(idx), nil
+}
+
+// TrySetBitAt will set the bit at the given index to the given value. Unlike SetBitAt, it returns
+// an *ErrIndexOutOfRange if the index exceeds the number of bits in the bitlist.
+func (r *RoaringBitlist) TrySetBitAt(idx uint64, val bool) error {
+	if idx >= r.size {
+		return &ErrIndexOutOfRange{Index: idx, Len: r.size}
+	}
+	r.SetBitAt(idx, val)
+	return nil
+}
+
+// Len returns the number of bits in the bitlist.
+func (r *RoaringBitlist) Len() uint64 {
+	return r.size
+}
+
+// Count returns the number of 1s in the bitlist.
+func (r *RoaringBitlist) Count() uint64 {
+	c := 0
+	for _, cont := range r.containers {
+		c += cont.count()
+	}
+	return uint64(c)
+}
+
+// Bytes returns the bits of the bitlist as an array of bytes, trimmed in the same way as
+// Bitlist64.Bytes.
+func (r *RoaringBitlist) Bytes() []byte {
+	return r.ToBitlist64().Bytes()
+}
+
+// BitIndices returns list of bit indexes of bitlist where value is set to true.
+func (r *RoaringBitlist) BitIndices() []int {
+	indices := make([]int, 0, r.Count())
+	var buf [chunkWords]uint64
+	for i, k := range r.keys {
+		for idx, word := range r.containers[i].words(&buf) {
+			for word != 0 {
+				indices = append(indices, int(k<<chunkBitsLog2)+idx<<wordSizeLog2+bits.TrailingZeros64(word))
+				word &= word - 1
+			}
+		}
+	}
+	return indices
+}
+
+// Or returns the OR result of the two bitlists (union). This method will return an error if the
+// bitlists are not the same length.
+func (r *RoaringBitlist) Or(c *RoaringBitlist) (*RoaringBitlist, error) {
+	return r.combine(c, opOr)
+}
+
+// And returns the AND result of the two bitlists (intersection). This method will return an error
+// if the bitlists are not the same length.
+func (r *RoaringBitlist) And(c *RoaringBitlist) (*RoaringBitlist, error) {
+	return r.combine(c, opAnd)
+}
+
+// Xor returns the XOR result of the two bitlists (symmetric difference). This method will return
+// an error if the bitlists are not the same length.
+func (r *RoaringBitlist) Xor(c *RoaringBitlist) (*RoaringBitlist, error) {
+	return r.combine(c, opXor)
+}
+
+// AndNot returns the AND NOT result of the two bitlists (difference). This method will return an
+// error if the bitlists are not the same length.
+func (r *RoaringBitlist) AndNot(c *RoaringBitlist) (*RoaringBitlist, error) {
+	return r.combine(c, opAndNot)
+}
+
+// OrBitlist64 returns the OR result of the compressed bitlist and a dense bitlist (union). This
+// method will return an error if the bitlists are not the same length.
+func (r *RoaringBitlist) OrBitlist64(c *Bitlist64) (*RoaringBitlist, error) {
+	return r.combine(c, opOr)
+}
+
+// AndBitlist64 returns the AND result of the compressed bitlist and a dense bitlist
+// (intersection). This method will return an error if the bitlists are not the same length.
+func (r *RoaringBitlist) AndBitlist64(c *Bitlist64) (*RoaringBitlist, error) {
+	return r.combine(c, opAnd)
+}
+
+// XorBitlist64 returns the XOR result of the compressed bitlist and a dense bitlist (symmetric
+// difference). This method will return an error if the bitlists are not the same length.
+func (r *RoaringBitlist) XorBitlist64(c *Bitlist64) (*RoaringBitlist, error) {
+	return r.combine(c, opXor)
+}
+
+// AndNotBitlist64 returns the AND NOT result of the compressed bitlist and a dense bitlist
+// (difference). This method will return an error if the bitlists are not the same length.
+func (r *RoaringBitlist) AndNotBitlist64(c *Bitlist64) (*RoaringBitlist, error) {
+	return r.combine(c, opAndNot)
+}
+
+// setOp is a word-wise set operation.
+type setOp int
+
+const (
+	opOr setOp = iota
+	opAnd
+	opXor
+	opAndNot
+)
+
+// chunkSource is implemented by the bitlists which can be combined with a RoaringBitlist.
+type chunkSource interface {
+	Len() uint64
+	// chunk returns the bits of the k-th chunk as a bitmap, which may be written into buf, or nil
+	// if no bit of the chunk is set. The returned bitmap must not be modified.
+	chunk(k uint64, buf *[chunkWords]uint64) *[chunkWords]uint64
+}
+
+// combine returns the result of op applied to r and c, one chunk at a time.
+func (r *RoaringBitlist) combine(c chunkSource, op setOp) (*RoaringBitlist, error) {
+	if r.size != c.Len() {
+		return nil, ErrBitlistDifferentLength
+	}
+
+	ret := NewRoaringBitlist(r.size)
+	var bufA, bufB, out [chunkWords]uint64
+	for k := uint64(0); k < r.numChunks(); k++ {
+		a, b := r.chunk(k, &bufA), c.chunk(k, &bufB)
+		switch {
+		case a == nil && b == nil:
+			continue
+		case (op == opAnd || op == opAndNot) && a == nil, op == opAnd && b == nil:
+			continue
+		}
+		if a == nil {
+			a = &zeroChunk
+		}
+		if b == nil {
+			b = &zeroChunk
+		}
+
+		switch op {
+		case opOr:
+			for idx := range out {
+				out[idx] = a[idx] | b[idx]
+			}
+		case opAnd:
+			for idx := range out {
+				out[idx] = a[idx] & b[idx]
+			}
+		case opXor:
+			for idx := range out {
+				out[idx] = a[idx] ^ b[idx]
+			}
+		case opAndNot:
+			for idx := range out {
+				out[idx] = a[idx] &^ b[idx]
+			}
+		}
+		if cont := newContainer(&out); cont != nil {
+			ret.keys = append(ret.keys, k)
+			ret.containers = append(ret.containers, cont)
+		}
+	}
+	return ret, nil
+}
+
+// chunk returns the bits of the k-th chunk of the bitlist, see chunkSource.
+func (r *RoaringBitlist) chunk(k uint64, buf *[chunkWords]uint64) *[chunkWords]uint64 {
+	i, ok := slices.BinarySearch(r.keys, k)
+	if !ok {
+		return nil
+	}
+	return r.containers[i].words(buf)
+}
+
+// chunk returns the bits of the k-th chunk of the bitlist, see chunkSource. Full chunks are
+// returned without copying, unless unused bits of the last word must be cleared.
+func (b *Bitlist64) chunk(k uint64, buf *[chunkWords]uint64) *[chunkWords]uint64 {
+	lo := k * uint64(chunkWords)
+	if lo >= uint64(len(b.data)) {
+		return nil
+	}
+	words := b.data[lo:]
+	if len(words) > chunkWords || (len(words) == chunkWords && b.size%wordSize == 0) {
+		return (*[chunkWords]uint64)(words[:chunkWords])
+	}
+	*buf = zeroChunk
+	copy(buf[:], words)
+	if b.size%wordSize != 0 {
+		// Leave out any unused bits set past the size.
+		buf[len(words)-1] &= allBitsSet >> (wordSize - b.size%wordSize)
+	}
+	return buf
+}
+
+// numChunks returns the number of chunks needed to hold all bits of the bitlist.
+func (r *RoaringBitlist) numChunks() uint64 {
+	return (r.size + chunkBits - 1) >> chunkBitsLog2
+}
+
+// newContainer returns the smallest container holding the bits of w, or nil if no bit is set. The
+// container does not share memory with w.
+func newContainer(w *[chunkWords]uint64) container {
+	card, numRuns := 0, 0
+	var carry uint64
+	for _, word := range w {
+		card += bits.OnesCount64(word)
+		// A run starts at every bit set whose lower neighbour is not set.
+		numRuns += bits.OnesCount64(word &^ (word<<1 | carry))
+		carry = word >> (wordSize - 1)
+	}
+	if card == 0 {
+		return nil
+	}
+
+	// Compare the sizes in bytes of the containers: 2 bytes per array element, 4 bytes per run,
+	// or the full bitmap.
+	if size := min(2*card, chunkWords*bytesInWord); 4*numRuns < size {
+		return newRunContainer(w, numRuns)
+	}
+	if card <= arrayMaxCount {
+		return newArrayContainer(w, card)
+	}
+	return &bitmapContainer{bits: *w, n: card}
+}
+
+// arrayContainer holds the indices of the bits set in a chunk, in ascending order.
+type arrayContainer []uint16
+
+// newArrayContainer returns the array container holding the card bits set in w.
+func newArrayContainer(w *[chunkWords]uint64, card int) arrayContainer {
+	a := make(arrayContainer, 0, card)
+	for idx, word := range w {
+		for word != 0 {
+			a = append(a, uint16(idx<<wordSizeLog2+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	return a
+}
+
+func (a arrayContainer) contains(x uint16) bool {
+	_, ok := slices.BinarySearch(a, x)
+	return ok
+}
+
+func (a arrayContainer) count() int {
+	return len(a)
+}
+
+func (a arrayContainer) words(buf *[chunkWords]uint64) *[chunkWords]uint64 {
+	*buf = zeroChunk
+	for _, x := range a {
+		buf[x>>wordSizeLog2] |= 1 << (x % uint16(wordSize))
+	}
+	return buf
+}
+
+func (a arrayContainer) set(x uint16, val bool) container {
+	i, ok := slices.BinarySearch(a, x)
+	switch {
+	case val && !ok && len(a) == arrayMaxCount:
+		// The array is full, move to a bitmap.
+		b := &bitmapContainer{n: len(a)}
+		a.words(&b.bits)
+		return b.set(x, true)
+	case val && !ok:
+		return slices.Insert(a, i, x)
+	case !val && ok && len(a) == 1:
+		return nil
+	case !val && ok:
+		return slices.Delete(a, i, i+1)
+	}
+	return a
+}
+
+// bitmapContainer holds the bits of a chunk as a plain bitmap.
+type bitmapContainer struct {
+	bits [chunkWords]uint64
+	// n is the number of bits set.
+	n int
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.bits[x>>wordSizeLog2]&(1<<(x%uint16(wordSize))) != 0
+}
+
+func (b *bitmapContainer) count() int {
+	return b.n
+}
+
+func (b *bitmapContainer) words(*[chunkWords]uint64) *[chunkWords]uint64 {
+	return &b.bits
+}
+
+func (b *bitmapContainer) set(x uint16, val bool) container {
+	if b.contains(x) == val {
+		return b
+	}
+
+	bit := uint64(1) << (x % uint16(wordSize))
+	if val {
+		b.bits[x>>wordSizeLog2] |= bit
+		b.n++
+		return b
+	}
+	b.bits[x>>wordSizeLog2] &^= bit
+	b.n--
+	if b.n <= arrayMaxCount {
+		// The array is now at most as large as the bitmap.
+		return newArrayContainer(&b.bits, b.n)
+	}
+	return b
+}
+
+// bitRun is a run of consecutive bits set, from start to last inclusive.
+type bitRun struct {
+	start, last uint16
+}
+
+// runContainer holds the bits of a chunk as runs of consecutive bits set, in ascending order.
+type runContainer []bitRun
+
+// newRunContainer returns the run container holding the numRuns runs of bits set in w.
+func newRunContainer(w *[chunkWords]uint64, numRuns int) runContainer {
+	r := make(runContainer, 0, numRuns)
+	from := uint64(0)
+	for {
+		start, ok := nextBitInChunk(w, from, false)
+		if !ok {
+			return r
+		}
+		end, ok := nextBitInChunk(w, start, true)
+		if !ok {
+			end = chunkBits
+		}
+		r = append(r, bitRun{start: uint16(start), last: uint16(end - 1)})
+		from = end
+	}
+}
+
+func (r runContainer) contains(x uint16) bool {
+	i := sort.Search(len(r), func(i int) bool { return r[i].last >= x })
+	return i < len(r) && r[i].start <= x
+}
+
+func (r runContainer) count() int {
+	c := 0
+	for _, run := range r {
+		c += int(run.last-run.start) + 1
+	}
+	return c
+}
+
+func (r runContainer) words(buf *[chunkWords]uint64) *[chunkWords]uint64 {
+	*buf = zeroChunk
+	for _, run := range r {
+		from, to := uint64(run.start), uint64(run.last)+1
+		for idx := from >> wordSizeLog2; idx <= (to-1)>>wordSizeLog2; idx++ {
+			buf[idx] |= rangeWordMask(idx, from, to)
+		}
+	}
+	return buf
+}
+
+func (r runContainer) set(x uint16, val bool) container {
+	if r.contains(x) == val {
+		return r
+	}
+
+	// Splitting or merging runs may change which container is the smallest, so rebuild the chunk.
+	var buf [chunkWords]uint64
+	r.words(&buf)
+	if val {
+		buf[x>>wordSizeLog2] |= 1 << (x % uint16(wordSize))
+	} else {
+		buf[x>>wordSizeLog2] &^= 1 << (x % uint16(wordSize))
+	}
+	return newContainer(&buf)
+}
+
+// nextBitInChunk returns the lowest index from `from` upwards whose bit is 1, or 0 if unset is
+// true. The second return value is false if there is no such bit in the chunk.
+func nextBitInChunk(w *[chunkWords]uint64, from uint64, unset bool) (uint64, bool) {
+	for idx := from >> wordSizeLog2; idx < uint64(chunkWords); idx++ {
+		word := w[idx]
+		if unset {
+			word = ^word
+		}
+		if idx == from>>wordSizeLog2 {
+			word &= allBitsSet << (from % wordSize)
+		}
+		if word != 0 {
+			return idx<<wordSizeLog2 + uint64(bits.TrailingZeros64(word)), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,232 @@
+package bitfield
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// roaringFixtures returns uncompressed bitlists of n bits with different densities and layouts,
+// keyed by name.
+func roaringFixtures(n uint64) map[string]*Bitlist64 {
+	r := rand.New(rand.NewSource(1))
+	random := func(p float64) *Bitlist64 {
+		b := NewBitlist64(n)
+		for i := uint64(0); i < n; i++ {
+			b.SetBitAt(i, r.Float64() < p)
+		}
+		return b
+	}
+
+	allSet := NewBitlist64(n)
+	if err := allSet.SetRange(0, n, true); err != nil {
+		panic(err)
+	}
+	runs := NewBitlist64(n)
+	for i := uint64(0); i+1000 <= n; i += 3000 {
+		if err := runs.SetRange(i, i+1000, true); err != nil {
+			panic(err)
+		}
+	}
+
+	return map[string]*Bitlist64{
+		"empty":   NewBitlist64(n),
+		"all set": allSet,
+		"runs":    runs,
+		"sparse":  random(0.01),
+		"half":    random(0.5),
+		"dense":   random(0.99),
+	}
+}
+
+func TestRoaringBitlist_Conversions(t *testing.T) {
+	for _, n := range []uint64{0, 1, 100, chunkBits, 200000} {
+		for name, b := range roaringFixtures(n) {
+			r := NewRoaringBitlistFromBitlist64(b)
+			if got := r.ToBitlist64(); !reflect.DeepEqual(got, b) {
+				t.Errorf("%s/%d: ToBitlist64() does not match the original bitlist", name, n)
+			}
+			if r.Len() != b.Len() || r.Count() != b.Count() {
+				t.Errorf("%s/%d: Len(), Count() = %d, %d, wanted %d, %d", name, n, r.Len(), r.Count(), b.Len(), b.Count())
+			}
+			if !bytes.Equal(r.Bytes(), b.Bytes()) {
+				t.Errorf("%s/%d: Bytes() does not match the original bitlist", name, n)
+			}
+			if !reflect.DeepEqual(r.BitIndices(), b.BitIndices()) {
+				t.Errorf("%s/%d: BitIndices() does not match the original bitlist", name, n)
+			}
+			for i := uint64(0); i < n+2; i += 97 {
+				if r.BitAt(i) != b.BitAt(i) {
+					t.Errorf("%s/%d: BitAt(%d) = %t, wanted %t", name, n, i, r.BitAt(i), b.BitAt(i))
+				}
+			}
+
+			rb, err := NewRoaringBitlistFromBitlist(b.ToBitlist())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(rb.ToBitlist(), b.ToBitlist()) {
+				t.Errorf("%s/%d: ToBitlist() does not match the original bitlist", name, n)
+			}
+		}
+	}
+}
+
+func TestRoaringBitlist_Containers(t *testing.T) {
+	fixtures := roaringFixtures(chunkBits)
+	tests := []struct {
+		name string
+		want container
+	}{
+		{name: "empty", want: nil},
+		{name: "all set", want: runContainer{}},
+		{name: "runs", want: runContainer{}},
+		{name: "sparse", want: arrayContainer{}},
+		{name: "half", want: &bitmapContainer{}},
+		{name: "dense", want: runContainer{}}, // The few gaps make few runs.
+	}
+
+	for _, tt := range tests {
+		r := NewRoaringBitlistFromBitlist64(fixtures[tt.name])
+		var got container
+		if len(r.containers) > 0 {
+			got = r.containers[0]
+		}
+		if reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+			t.Errorf("%s: container is %T, wanted %T", tt.name, got, tt.want)
+		}
+	}
+
+	if r := NewRoaringBitlistFromBitlist64(fixtures["all set"]); !reflect.DeepEqual(r.containers[0], runContainer{{0, 0xffff}}) {
+		t.Errorf("all set: container is %v, wanted a single run", r.containers[0])
+	}
+}
+
+func TestRoaringBitlist_SetBitAt(t *testing.T) {
+	r := NewRoaringBitlist(200000)
+	want := NewBitlist64(200000)
+	check := func(step string, wantType container) {
+		t.Helper()
+		if !reflect.DeepEqual(r.ToBitlist64(), want) || r.Count() != want.Count() {
+			t.Fatalf("%s: bitlist does not match", step)
+		}
+		if reflect.TypeOf(r.containers[0]) != reflect.TypeOf(wantType) {
+			t.Fatalf("%s: container is %T, wanted %T", step, r.containers[0], wantType)
+		}
+	}
+
+	// Every other bit, which fills an array container.
+	for i := uint64(0); i < 2*arrayMaxCount; i += 2 {
+		r.SetBitAt(i, true)
+		want.SetBitAt(i, true)
+	}
+	check("full array", arrayContainer{})
+	r.SetBitAt(2*arrayMaxCount, true)
+	want.SetBitAt(2*arrayMaxCount, true)
+	check("array past capacity", &bitmapContainer{})
+	r.SetBitAt(0, false)
+	want.SetBitAt(0, false)
+	check("bitmap below capacity", arrayContainer{})
+
+	// A single run, then split it.
+	r = NewRoaringBitlistFromBitlist64(NewBitlist64From(repeatWord(chunkWords, allBitsSet)))
+	want = NewBitlist64From(repeatWord(chunkWords, allBitsSet))
+	check("run", runContainer{})
+	r.SetBitAt(500, false)
+	want.SetBitAt(500, false)
+	check("split run", runContainer{})
+	r.SetBitAt(500, true)
+	want.SetBitAt(500, true)
+	check("merged run", runContainer{})
+
+	// Clearing the last bit drops the container.
+	r = NewRoaringBitlist(100)
+	r.SetBitAt(7, true)
+	r.SetBitAt(7, false)
+	r.SetBitAt(100, true)
+	if len(r.keys) != 0 || len(r.containers) != 0 || r.Count() != 0 {
+		t.Errorf("Containers left after clearing all bits: %v", r.keys)
+	}
+}
+
+func TestRoaringBitlist_TryBitAt(t *testing.T) {
+	for _, n := range []uint64{0, 1, 100, chunkBits + 1} {
+		checkStrictAccessors(t, NewRoaringBitlist(n))
+	}
+}
+
+func TestRoaringBitlist_SetOps(t *testing.T) {
+	for _, n := range []uint64{100, 200000} {
+		fixtures := roaringFixtures(n)
+		for nameA, a := range fixtures {
+			for nameB, b := range fixtures {
+				ra, rb := NewRoaringBitlistFromBitlist64(a), NewRoaringBitlistFromBitlist64(b)
+				for _, op := range []struct {
+					name    string
+					dense   func(a, b *Bitlist64) (*Bitlist64, error)
+					roaring func(a, b *RoaringBitlist) (*RoaringBitlist, error)
+					mixed   func(a *RoaringBitlist, b *Bitlist64) (*RoaringBitlist, error)
+				}{
+					{"Or", (*Bitlist64).Or, (*RoaringBitlist).Or, (*RoaringBitlist).OrBitlist64},
+					{"And", (*Bitlist64).And, (*RoaringBitlist).And, (*RoaringBitlist).AndBitlist64},
+					{"Xor", (*Bitlist64).Xor, (*RoaringBitlist).Xor, (*RoaringBitlist).XorBitlist64},
+					{"AndNot", (*Bitlist64).AndNot, (*RoaringBitlist).AndNot, (*RoaringBitlist).AndNotBitlist64},
+				} {
+					want, err := op.dense(a, b)
+					if err != nil {
+						t.Fatal(err)
+					}
+					got, err := op.roaring(ra, rb)
+					if err != nil || !reflect.DeepEqual(got.ToBitlist64(), want) {
+						t.Errorf("%d: %s.%s(%s) does not match the dense result, err=%v", n, nameA, op.name, nameB, err)
+					}
+					got, err = op.mixed(ra, b)
+					if err != nil || !reflect.DeepEqual(got.ToBitlist64(), want) {
+						t.Errorf("%d: %s.%sBitlist64(%s) does not match the dense result, err=%v", n, nameA, op.name, nameB, err)
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("check errors", func(t *testing.T) {
+		r := NewRoaringBitlist(100)
+		if _, err := r.Or(NewRoaringBitlist(101)); !errors.Is(err, ErrBitlistDifferentLength) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if _, err := r.AndBitlist64(NewBitlist64(64)); !errors.Is(err, ErrBitlistDifferentLength) {
+			t.Errorf("Wrong error returned. Wanted %v, got %v", ErrBitlistDifferentLength, err)
+		}
+		if _, err := NewRoaringBitlistFromBitlist(Bitlist{}); err != nil {
+			t.Errorf("Unexpected error for an empty bitlist: %v", err)
+		}
+	})
+
+	t.Run("unused bits", func(t *testing.T) {
+		// Bits set past the size are not part of the bitlist.
+		b, err := NewBitlist64FromBytes(3, []byte{0xfb})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &Bitlist64{size: 3, data: []uint64{0x03}}
+		r := NewRoaringBitlistFromBitlist64(b)
+		if got := r.ToBitlist64(); !reflect.DeepEqual(got, want) || r.Count() != 2 {
+			t.Errorf("NewRoaringBitlistFromBitlist64(%v) = %v with %d bits set, wanted %v", b, got, r.Count(), want)
+		}
+		got, err := NewRoaringBitlist(3).OrBitlist64(b)
+		if err != nil || !reflect.DeepEqual(got.ToBitlist64(), want) {
+			t.Errorf("OrBitlist64(%v) = %v, %v, wanted %v", b, got.ToBitlist64(), err, want)
+		}
+	})
+}
+
+// repeatWord returns n copies of word.
+func repeatWord(n int, word uint64) []uint64 {
+	ret := make([]uint64, n)
+	for i := range ret {
+		ret[i] = word
+	}
+	return ret
+}